@@ -52,6 +52,24 @@ func ToUpperInPlace(b []byte) []byte {
 	return b
 }
 
+// ToUpperByte is the single byte form of ToUpperInPlace.
+func ToUpperByte(c byte) byte {
+	return toUpperTable[c]
+}
+
+// EqualFold reports whether a and b are equal under ASCII case folding.
+func EqualFold(a, b []byte) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if toUpperTable[a[i]] != toUpperTable[b[i]] {
+			return false
+		}
+	}
+	return true
+}
+
 func PutBytes(dst []byte, bs ...[]byte) (int, []byte) {
 	off := 0
 	for _, b := range bs {
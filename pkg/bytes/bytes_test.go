@@ -191,6 +191,42 @@ func TestToUpperInPlace(t *testing.T) {
 	}
 }
 
+func TestEqualFold(t *testing.T) {
+	testCases := []struct {
+		name     string
+		a, b     []byte
+		expected bool
+	}{
+		{name: "both empty", a: []byte{}, b: nil, expected: true},
+		{name: "same case", a: []byte("global"), b: []byte("global"), expected: true},
+		{name: "different case", a: []byte("GloBal"), b: []byte("GLOBAL"), expected: true},
+		{name: "different length", a: []byte("global"), b: []byte("globals"), expected: false},
+		{name: "different letters", a: []byte("global"), b: []byte("glocal"), expected: false},
+		{name: "symbols are not folded", a: []byte("a@"), b: []byte("A`"), expected: false},
+		{name: "non-ascii compared as is", a: []byte("café"), b: []byte("CAFé"), expected: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := EqualFold(tc.a, tc.b); got != tc.expected {
+				t.Errorf("EqualFold(%q, %q) = %t; want %t", tc.a, tc.b, got, tc.expected)
+			}
+		})
+	}
+}
+
+func TestToUpperByte(t *testing.T) {
+	for c := 0; c < 256; c++ {
+		expected := bytes.ToUpper([]byte{byte(c)})
+		if c >= 0x80 {
+			expected = []byte{byte(c)}
+		}
+		if got := ToUpperByte(byte(c)); got != expected[0] {
+			t.Errorf("ToUpperByte(%d) = %d; want %d", c, got, expected[0])
+		}
+	}
+}
+
 // --- Benchmarks ---
 
 var benchData = []byte("TheQuickBrownFoxJumpsOverTheLazyDogAndTheQuickBrownFoxJumpsOverTheLazyDog")
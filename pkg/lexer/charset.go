@@ -0,0 +1,57 @@
+package lexer
+
+// Charset is the character set of the input, see Options.Charset.
+type Charset uint8
+
+const (
+	// CharsetUTF8MB4 also stands for utf8mb3. Outside strings and quoted
+	// identifiers, bytes from 0x80 must be valid UTF-8.
+	CharsetUTF8MB4 Charset = iota
+	CharsetGBK
+	CharsetBig5
+	CharsetSJIS
+	// CharsetLatin1 stands for the single-byte charsets and every other
+	// charset whose characters never hold ASCII bytes, like ujis and
+	// euckr: any byte from 0x80 may be part of an identifier.
+	CharsetLatin1
+)
+
+// mbLen returns the length of the multibyte character b starts with, or
+// 0 if b does not start with one. In GBK, Big5 and SJIS the second byte
+// may be a backslash, a backtick or another ASCII byte not to be read alone.
+func (cs Charset) mbLen(b []byte) int {
+	if len(b) < 2 {
+		return 0
+	}
+	lead, trail := b[0], b[1]
+	switch cs {
+	case CharsetGBK:
+		if lead >= 0x81 && lead <= 0xFE && (trail >= 0x40 && trail <= 0x7E || trail >= 0x80 && trail <= 0xFE) {
+			return 2
+		}
+	case CharsetBig5:
+		if lead >= 0xA1 && lead <= 0xF9 && (trail >= 0x40 && trail <= 0x7E || trail >= 0xA1 && trail <= 0xFE) {
+			return 2
+		}
+	case CharsetSJIS:
+		if (lead >= 0x81 && lead <= 0x9F || lead >= 0xE0 && lead <= 0xFC) &&
+			(trail >= 0x40 && trail <= 0x7E || trail >= 0x80 && trail <= 0xFC) {
+			return 2
+		}
+	}
+	return 0
+}
+
+// skipMultibyte moves past the rest of the multibyte character whose
+// first byte c was just read, reporting whether there was one.
+func (l *Lexer) skipMultibyte(c byte) bool {
+	if c < 0x80 || l.opts.Charset == CharsetUTF8MB4 {
+		return false
+	}
+	n := l.opts.Charset.mbLen(l.sql[l.curr-1:])
+	if n == 0 {
+		return false
+	}
+	l.curr += n - 1
+	return true
+}
@@ -0,0 +1,42 @@
+package lexer
+
+import "strconv"
+
+type ErrorKind uint8
+
+const (
+	ErrorUnterminatedString ErrorKind = iota + 1
+	ErrorUnterminatedComment
+	ErrorUnterminatedIdentifier
+	ErrorInvalidUTF8
+	// valid UTF-8 but not allowed outside strings and quoted identifiers,
+	// like characters beyond U+FFFF
+	ErrorInvalidCharacter
+)
+
+func (k ErrorKind) String() string {
+	switch k {
+	case ErrorUnterminatedString:
+		return "unterminated string"
+	case ErrorUnterminatedComment:
+		return "unterminated comment"
+	case ErrorUnterminatedIdentifier:
+		return "unterminated quoted identifier"
+	case ErrorInvalidUTF8:
+		return "invalid UTF-8"
+	case ErrorInvalidCharacter:
+		return "invalid character"
+	}
+	return "unknown error"
+}
+
+// Error describes malformed input, such as a query truncated in the
+// middle of a string. Offset is where the offending token starts.
+type Error struct {
+	Kind   ErrorKind
+	Offset int
+}
+
+func (e *Error) Error() string {
+	return e.Kind.String() + " at offset " + strconv.Itoa(e.Offset)
+}
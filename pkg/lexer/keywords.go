@@ -1,689 +1,159 @@
 package lexer
 
-// Source: https://github.com/pingcap/tidb/blob/master/pkg/lexer/keywords.go
-
+// Keyword attributes live above the token attributes so both can be set
+// on the same token.
 const (
-	KeywordAttrBuiltInFunction Attr = 1 << iota
+	KeywordAttrBuiltInFunction Attr = 1 << (iota + 32)
 	KeywordAttrBuiltinLiteral
 	KeywordAttrReserved
+	KeywordAttrAggregateFunction
+	KeywordAttrWindowFunction
 )
 
+const keywordAttrFunction = KeywordAttrBuiltInFunction | KeywordAttrAggregateFunction | KeywordAttrWindowFunction
+
 type BuiltInKeywordType struct {
 	Attr Attr
 }
 
-var _builtinnKeywords = map[string]BuiltInKeywordType{
-	// builtin functions
+type dialectSet uint8
+
+func (s dialectSet) has(d Dialect) bool {
+	return s&(1<<d) != 0
+}
+
+type keyword struct {
+	attr     Attr
+	dialects dialectSet
+	reserved dialectSet
+	// an operand usually follows, see Lexer.contextAfter
+	beforeOperand bool
+}
+
+func concat(lists ...[]string) []string {
+	var words []string
+	for _, l := range lists {
+		words = append(words, l...)
+	}
+	return words
+}
+
+var dialectKeywords = [...]struct {
+	reserved, nonReserved []string
+}{
+	DialectMySQL80: {
+		reserved:    concat(mysqlReserved, mysql80Reserved, mysqlMasterReserved),
+		nonReserved: concat(mysqlKeywords, mysql80Keywords, mysqlMasterKeywords, mysql80MasterKeywords),
+	},
+	DialectMySQL57: {
+		reserved:    concat(mysqlReserved, mysqlMasterReserved),
+		nonReserved: concat(mysqlKeywords, mysql57Keywords, mysqlMasterKeywords),
+	},
+	DialectMySQL84: {
+		reserved:    concat(mysqlReserved, mysql80Reserved, mysql84Reserved),
+		nonReserved: concat(mysqlKeywords, mysql80Keywords, mysql84Keywords),
+	},
+	DialectMariaDB10: {
+		reserved:    mariadbReserved,
+		nonReserved: concat(mysqlKeywords, mysqlMasterKeywords, mariadbKeywords),
+	},
+	DialectMariaDB11: {
+		reserved:    mariadbReserved,
+		nonReserved: concat(mysqlKeywords, mysqlMasterKeywords, mariadbKeywords, mariadb11Keywords),
+	},
+	DialectTiDB: {
+		reserved:    tidbReserved,
+		nonReserved: concat(tidbKeywords, tidbOnlyKeywords),
+	},
+}
+
+// operandKeywords are the reserved keywords after which a non-reserved
+// keyword is taken for a column or table name when it also ends the
+// operand, like `name` in `SELECT name FROM t`.
+var operandKeywords = []string{
+	"AND", "AS", "BETWEEN", "BY", "CASE", "DISTINCT", "DIV", "ELSE", "FROM",
+	"HAVING", "INTO", "JOIN", "LIKE", "MOD", "NOT", "ON", "OR", "REGEXP",
+	"RLIKE", "SELECT", "SET", "TABLE", "THEN", "UPDATE", "WHEN", "WHERE", "XOR",
+}
+
+var keywordAttrs = map[string]Attr{
+	"FALSE": KeywordAttrBuiltinLiteral | TokenAttrLiteralBoolean,
+	"NULL":  KeywordAttrBuiltinLiteral | TokenAttrLiteralNull,
+	"TRUE":  KeywordAttrBuiltinLiteral | TokenAttrLiteralBoolean,
+}
+
+// keywords holds the keywords of every dialect, each entry telling in
+// which dialects the word is a keyword and reserved, and the built-in
+// function names.
+var keywords = newKeywordHash(func() map[string]keyword {
+	m := make(map[string]keyword)
+	for d, kw := range dialectKeywords {
+		for _, w := range kw.nonReserved {
+			k := m[w]
+			k.dialects |= 1 << d
+			m[w] = k
+		}
+		for _, w := range kw.reserved {
+			k := m[w]
+			k.dialects |= 1 << d
+			k.reserved |= 1 << d
+			m[w] = k
+		}
+	}
+	for _, w := range operandKeywords {
+		k := m[w]
+		k.beforeOperand = true
+		m[w] = k
+	}
+	for w, attr := range keywordAttrs {
+		k := m[w]
+		k.attr |= attr
+		m[w] = k
+	}
+	for _, fn := range []struct {
+		names []string
+		attr  Attr
+	}{
+		{builtinFunctions, KeywordAttrBuiltInFunction},
+		{aggregateFunctions, KeywordAttrBuiltInFunction | KeywordAttrAggregateFunction},
+		{windowFunctions, KeywordAttrBuiltInFunction | KeywordAttrWindowFunction},
+	} {
+		for _, w := range fn.names {
+			k := m[w]
+			k.attr |= fn.attr
+			m[w] = k
+		}
+	}
+	return m
+}())
+
+// IsKeyword reports whether word, in any case, is a keyword of dialect.
+func IsKeyword(word []byte, dialect Dialect) bool {
+	_, ok := isBuiltInKeyword(word, hashFold(word), dialect)
+	return ok
+}
 
-	// keywords
-	"ADD":                            BuiltInKeywordType{},
-	"ALL":                            BuiltInKeywordType{},
-	"ALTER":                          BuiltInKeywordType{},
-	"ANALYZE":                        BuiltInKeywordType{},
-	"AND":                            BuiltInKeywordType{},
-	"ARRAY":                          BuiltInKeywordType{},
-	"AS":                             BuiltInKeywordType{},
-	"ASC":                            BuiltInKeywordType{},
-	"BETWEEN":                        BuiltInKeywordType{},
-	"BIGINT":                         BuiltInKeywordType{},
-	"BINARY":                         BuiltInKeywordType{},
-	"BLOB":                           BuiltInKeywordType{},
-	"BOTH":                           BuiltInKeywordType{},
-	"BY":                             BuiltInKeywordType{},
-	"CALL":                           BuiltInKeywordType{},
-	"CASCADE":                        BuiltInKeywordType{},
-	"CASE":                           BuiltInKeywordType{},
-	"CHANGE":                         BuiltInKeywordType{},
-	"CHAR":                           BuiltInKeywordType{},
-	"CHARACTER":                      BuiltInKeywordType{},
-	"CHECK":                          BuiltInKeywordType{},
-	"COLLATE":                        BuiltInKeywordType{},
-	"COLUMN":                         BuiltInKeywordType{},
-	"CONSTRAINT":                     BuiltInKeywordType{},
-	"CONTINUE":                       BuiltInKeywordType{},
-	"CONVERT":                        BuiltInKeywordType{},
-	"CREATE":                         BuiltInKeywordType{},
-	"CROSS":                          BuiltInKeywordType{},
-	"CUME_DIST":                      BuiltInKeywordType{},
-	"CURRENT_DATE":                   BuiltInKeywordType{},
-	"CURRENT_ROLE":                   BuiltInKeywordType{},
-	"CURRENT_TIME":                   BuiltInKeywordType{},
-	"CURRENT_TIMESTAMP":              BuiltInKeywordType{},
-	"CURRENT_USER":                   BuiltInKeywordType{},
-	"CURSOR":                         BuiltInKeywordType{},
-	"DATABASE":                       BuiltInKeywordType{},
-	"DATABASES":                      BuiltInKeywordType{},
-	"DAY_HOUR":                       BuiltInKeywordType{},
-	"DAY_MICROSECOND":                BuiltInKeywordType{},
-	"DAY_MINUTE":                     BuiltInKeywordType{},
-	"DAY_SECOND":                     BuiltInKeywordType{},
-	"DECIMAL":                        BuiltInKeywordType{},
-	"DEFAULT":                        BuiltInKeywordType{},
-	"DELAYED":                        BuiltInKeywordType{},
-	"DELETE":                         BuiltInKeywordType{},
-	"DENSE_RANK":                     BuiltInKeywordType{},
-	"DESC":                           BuiltInKeywordType{},
-	"DESCRIBE":                       BuiltInKeywordType{},
-	"DISTINCT":                       BuiltInKeywordType{},
-	"DISTINCTROW":                    BuiltInKeywordType{},
-	"DIV":                            BuiltInKeywordType{},
-	"DOUBLE":                         BuiltInKeywordType{},
-	"DROP":                           BuiltInKeywordType{},
-	"DUAL":                           BuiltInKeywordType{},
-	"ELSE":                           BuiltInKeywordType{},
-	"ELSEIF":                         BuiltInKeywordType{},
-	"ENCLOSED":                       BuiltInKeywordType{},
-	"ESCAPED":                        BuiltInKeywordType{},
-	"EXCEPT":                         BuiltInKeywordType{},
-	"EXISTS":                         BuiltInKeywordType{},
-	"EXIT":                           BuiltInKeywordType{},
-	"EXPLAIN":                        BuiltInKeywordType{},
-	"FALSE":                          BuiltInKeywordType{Attr: KeywordAttrBuiltinLiteral},
-	"FETCH":                          BuiltInKeywordType{},
-	"FIRST_VALUE":                    BuiltInKeywordType{},
-	"FLOAT":                          BuiltInKeywordType{},
-	"FLOAT4":                         BuiltInKeywordType{},
-	"FLOAT8":                         BuiltInKeywordType{},
-	"FOR":                            BuiltInKeywordType{},
-	"FORCE":                          BuiltInKeywordType{},
-	"FOREIGN":                        BuiltInKeywordType{},
-	"FROM":                           BuiltInKeywordType{},
-	"FULLTEXT":                       BuiltInKeywordType{},
-	"GENERATED":                      BuiltInKeywordType{},
-	"GRANT":                          BuiltInKeywordType{},
-	"GROUP":                          BuiltInKeywordType{},
-	"GROUPS":                         BuiltInKeywordType{},
-	"HAVING":                         BuiltInKeywordType{},
-	"HIGH_PRIORITY":                  BuiltInKeywordType{},
-	"HOUR_MICROSECOND":               BuiltInKeywordType{},
-	"HOUR_MINUTE":                    BuiltInKeywordType{},
-	"HOUR_SECOND":                    BuiltInKeywordType{},
-	"IF":                             BuiltInKeywordType{},
-	"IGNORE":                         BuiltInKeywordType{},
-	"ILIKE":                          BuiltInKeywordType{},
-	"IN":                             BuiltInKeywordType{},
-	"INDEX":                          BuiltInKeywordType{},
-	"INFILE":                         BuiltInKeywordType{},
-	"INNER":                          BuiltInKeywordType{},
-	"INOUT":                          BuiltInKeywordType{},
-	"INSERT":                         BuiltInKeywordType{},
-	"INT":                            BuiltInKeywordType{},
-	"INT1":                           BuiltInKeywordType{},
-	"INT2":                           BuiltInKeywordType{},
-	"INT3":                           BuiltInKeywordType{},
-	"INT4":                           BuiltInKeywordType{},
-	"INT8":                           BuiltInKeywordType{},
-	"INTEGER":                        BuiltInKeywordType{},
-	"INTERSECT":                      BuiltInKeywordType{},
-	"INTERVAL":                       BuiltInKeywordType{},
-	"INTO":                           BuiltInKeywordType{},
-	"IS":                             BuiltInKeywordType{},
-	"ITERATE":                        BuiltInKeywordType{},
-	"JOIN":                           BuiltInKeywordType{},
-	"KEY":                            BuiltInKeywordType{},
-	"KEYS":                           BuiltInKeywordType{},
-	"KILL":                           BuiltInKeywordType{},
-	"LAG":                            BuiltInKeywordType{},
-	"LAST_VALUE":                     BuiltInKeywordType{},
-	"LEAD":                           BuiltInKeywordType{},
-	"LEADING":                        BuiltInKeywordType{},
-	"LEAVE":                          BuiltInKeywordType{},
-	"LEFT":                           BuiltInKeywordType{},
-	"LIKE":                           BuiltInKeywordType{},
-	"LIMIT":                          BuiltInKeywordType{},
-	"LINEAR":                         BuiltInKeywordType{},
-	"LINES":                          BuiltInKeywordType{},
-	"LOAD":                           BuiltInKeywordType{},
-	"LOCALTIME":                      BuiltInKeywordType{},
-	"LOCALTIMESTAMP":                 BuiltInKeywordType{},
-	"LOCK":                           BuiltInKeywordType{},
-	"LONG":                           BuiltInKeywordType{},
-	"LONGBLOB":                       BuiltInKeywordType{},
-	"LONGTEXT":                       BuiltInKeywordType{},
-	"LOW_PRIORITY":                   BuiltInKeywordType{},
-	"MATCH":                          BuiltInKeywordType{},
-	"MAXVALUE":                       BuiltInKeywordType{},
-	"MEDIUMBLOB":                     BuiltInKeywordType{},
-	"MEDIUMINT":                      BuiltInKeywordType{},
-	"MEDIUMTEXT":                     BuiltInKeywordType{},
-	"MIDDLEINT":                      BuiltInKeywordType{},
-	"MINUTE_MICROSECOND":             BuiltInKeywordType{},
-	"MINUTE_SECOND":                  BuiltInKeywordType{},
-	"MOD":                            BuiltInKeywordType{},
-	"NATURAL":                        BuiltInKeywordType{},
-	"NOT":                            BuiltInKeywordType{},
-	"NO_WRITE_TO_BINLOG":             BuiltInKeywordType{},
-	"NTH_VALUE":                      BuiltInKeywordType{},
-	"NTILE":                          BuiltInKeywordType{},
-	"NULL":                           BuiltInKeywordType{Attr: KeywordAttrBuiltinLiteral},
-	"NUMERIC":                        BuiltInKeywordType{},
-	"OF":                             BuiltInKeywordType{},
-	"ON":                             BuiltInKeywordType{},
-	"OPTIMIZE":                       BuiltInKeywordType{},
-	"OPTION":                         BuiltInKeywordType{},
-	"OPTIONALLY":                     BuiltInKeywordType{},
-	"OR":                             BuiltInKeywordType{},
-	"ORDER":                          BuiltInKeywordType{},
-	"OUT":                            BuiltInKeywordType{},
-	"OUTER":                          BuiltInKeywordType{},
-	"OUTFILE":                        BuiltInKeywordType{},
-	"OVER":                           BuiltInKeywordType{},
-	"PARTITION":                      BuiltInKeywordType{},
-	"PERCENT_RANK":                   BuiltInKeywordType{},
-	"PRECISION":                      BuiltInKeywordType{},
-	"PRIMARY":                        BuiltInKeywordType{},
-	"PROCEDURE":                      BuiltInKeywordType{},
-	"RANGE":                          BuiltInKeywordType{},
-	"RANK":                           BuiltInKeywordType{},
-	"READ":                           BuiltInKeywordType{},
-	"REAL":                           BuiltInKeywordType{},
-	"RECURSIVE":                      BuiltInKeywordType{},
-	"REFERENCES":                     BuiltInKeywordType{},
-	"REGEXP":                         BuiltInKeywordType{},
-	"RELEASE":                        BuiltInKeywordType{},
-	"RENAME":                         BuiltInKeywordType{},
-	"REPEAT":                         BuiltInKeywordType{},
-	"REPLACE":                        BuiltInKeywordType{},
-	"REQUIRE":                        BuiltInKeywordType{},
-	"RESTRICT":                       BuiltInKeywordType{},
-	"REVOKE":                         BuiltInKeywordType{},
-	"RIGHT":                          BuiltInKeywordType{},
-	"RLIKE":                          BuiltInKeywordType{},
-	"ROW":                            BuiltInKeywordType{},
-	"ROWS":                           BuiltInKeywordType{},
-	"ROW_NUMBER":                     BuiltInKeywordType{},
-	"SECOND_MICROSECOND":             BuiltInKeywordType{},
-	"SELECT":                         BuiltInKeywordType{},
-	"SET":                            BuiltInKeywordType{},
-	"SHOW":                           BuiltInKeywordType{},
-	"SMALLINT":                       BuiltInKeywordType{},
-	"SPATIAL":                        BuiltInKeywordType{},
-	"SQL":                            BuiltInKeywordType{},
-	"SQLEXCEPTION":                   BuiltInKeywordType{},
-	"SQLSTATE":                       BuiltInKeywordType{},
-	"SQLWARNING":                     BuiltInKeywordType{},
-	"SQL_BIG_RESULT":                 BuiltInKeywordType{},
-	"SQL_CALC_FOUND_ROWS":            BuiltInKeywordType{},
-	"SQL_SMALL_RESULT":               BuiltInKeywordType{},
-	"SSL":                            BuiltInKeywordType{},
-	"STARTING":                       BuiltInKeywordType{},
-	"STORED":                         BuiltInKeywordType{},
-	"STRAIGHT_JOIN":                  BuiltInKeywordType{},
-	"TABLE":                          BuiltInKeywordType{},
-	"TABLESAMPLE":                    BuiltInKeywordType{},
-	"TERMINATED":                     BuiltInKeywordType{},
-	"THEN":                           BuiltInKeywordType{},
-	"TIDB_CURRENT_TSO":               BuiltInKeywordType{},
-	"TINYBLOB":                       BuiltInKeywordType{},
-	"TINYINT":                        BuiltInKeywordType{},
-	"TINYTEXT":                       BuiltInKeywordType{},
-	"TO":                             BuiltInKeywordType{},
-	"TRAILING":                       BuiltInKeywordType{},
-	"TRIGGER":                        BuiltInKeywordType{},
-	"TRUE":                           BuiltInKeywordType{Attr: KeywordAttrBuiltinLiteral},
-	"UNION":                          BuiltInKeywordType{},
-	"UNIQUE":                         BuiltInKeywordType{},
-	"UNLOCK":                         BuiltInKeywordType{},
-	"UNSIGNED":                       BuiltInKeywordType{},
-	"UNTIL":                          BuiltInKeywordType{},
-	"UPDATE":                         BuiltInKeywordType{},
-	"USAGE":                          BuiltInKeywordType{},
-	"USE":                            BuiltInKeywordType{},
-	"USING":                          BuiltInKeywordType{},
-	"UTC_DATE":                       BuiltInKeywordType{},
-	"UTC_TIME":                       BuiltInKeywordType{},
-	"UTC_TIMESTAMP":                  BuiltInKeywordType{},
-	"VALUES":                         BuiltInKeywordType{},
-	"VARBINARY":                      BuiltInKeywordType{},
-	"VARCHAR":                        BuiltInKeywordType{},
-	"VARCHARACTER":                   BuiltInKeywordType{},
-	"VARYING":                        BuiltInKeywordType{},
-	"VIRTUAL":                        BuiltInKeywordType{},
-	"WHEN":                           BuiltInKeywordType{},
-	"WHERE":                          BuiltInKeywordType{},
-	"WHILE":                          BuiltInKeywordType{},
-	"WINDOW":                         BuiltInKeywordType{},
-	"WITH":                           BuiltInKeywordType{},
-	"WRITE":                          BuiltInKeywordType{},
-	"XOR":                            BuiltInKeywordType{},
-	"YEAR_MONTH":                     BuiltInKeywordType{},
-	"ZEROFILL":                       BuiltInKeywordType{},
-	"ACCOUNT":                        BuiltInKeywordType{},
-	"ACTION":                         BuiltInKeywordType{},
-	"ADD_COLUMNAR_REPLICA_ON_DEMAND": BuiltInKeywordType{},
-	"ADVISE":                         BuiltInKeywordType{},
-	"AFTER":                          BuiltInKeywordType{},
-	"AGAINST":                        BuiltInKeywordType{},
-	"AGO":                            BuiltInKeywordType{},
-	"ALGORITHM":                      BuiltInKeywordType{},
-	"ALWAYS":                         BuiltInKeywordType{},
-	"ANY":                            BuiltInKeywordType{},
-	"APPLY":                          BuiltInKeywordType{},
-	"ASCII":                          BuiltInKeywordType{},
-	"ATTRIBUTE":                      BuiltInKeywordType{},
-	"ATTRIBUTES":                     BuiltInKeywordType{},
-	"AUTO_ID_CACHE":                  BuiltInKeywordType{},
-	"AUTO_INCREMENT":                 BuiltInKeywordType{},
-	"AUTO_RANDOM":                    BuiltInKeywordType{},
-	"AUTO_RANDOM_BASE":               BuiltInKeywordType{},
-	"AVG":                            BuiltInKeywordType{},
-	"AVG_ROW_LENGTH":                 BuiltInKeywordType{},
-	"BACKEND":                        BuiltInKeywordType{},
-	"BACKUP":                         BuiltInKeywordType{},
-	"BACKUPS":                        BuiltInKeywordType{},
-	"BDR":                            BuiltInKeywordType{},
-	"BEGIN":                          BuiltInKeywordType{},
-	"BERNOULLI":                      BuiltInKeywordType{},
-	"BINDING":                        BuiltInKeywordType{},
-	"BINDINGS":                       BuiltInKeywordType{},
-	"BINDING_CACHE":                  BuiltInKeywordType{},
-	"BINLOG":                         BuiltInKeywordType{},
-	"BIT":                            BuiltInKeywordType{},
-	"BLOCK":                          BuiltInKeywordType{},
-	"BOOL":                           BuiltInKeywordType{},
-	"BOOLEAN":                        BuiltInKeywordType{},
-	"BTREE":                          BuiltInKeywordType{},
-	"BYTE":                           BuiltInKeywordType{},
-	"CACHE":                          BuiltInKeywordType{},
-	"CALIBRATE":                      BuiltInKeywordType{},
-	"CAPTURE":                        BuiltInKeywordType{},
-	"CASCADED":                       BuiltInKeywordType{},
-	"CAUSAL":                         BuiltInKeywordType{},
-	"CHAIN":                          BuiltInKeywordType{},
-	"CHARSET":                        BuiltInKeywordType{},
-	"CHECKPOINT":                     BuiltInKeywordType{},
-	"CHECKSUM":                       BuiltInKeywordType{},
-	"CHECKSUM_CONCURRENCY":           BuiltInKeywordType{},
-	"CIPHER":                         BuiltInKeywordType{},
-	"CLEANUP":                        BuiltInKeywordType{},
-	"CLIENT":                         BuiltInKeywordType{},
-	"CLIENT_ERRORS_SUMMARY":          BuiltInKeywordType{},
-	"CLOSE":                          BuiltInKeywordType{},
-	"CLUSTER":                        BuiltInKeywordType{},
-	"CLUSTERED":                      BuiltInKeywordType{},
-	"COALESCE":                       BuiltInKeywordType{},
-	"COLLATION":                      BuiltInKeywordType{},
-	"COLUMNAR":                       BuiltInKeywordType{},
-	"COLUMNS":                        BuiltInKeywordType{},
-	"COLUMN_FORMAT":                  BuiltInKeywordType{},
-	"COMMENT":                        BuiltInKeywordType{},
-	"COMMIT":                         BuiltInKeywordType{},
-	"COMMITTED":                      BuiltInKeywordType{},
-	"COMPACT":                        BuiltInKeywordType{},
-	"COMPRESSED":                     BuiltInKeywordType{},
-	"COMPRESSION":                    BuiltInKeywordType{},
-	"COMPRESSION_LEVEL":              BuiltInKeywordType{},
-	"COMPRESSION_TYPE":               BuiltInKeywordType{},
-	"CONCURRENCY":                    BuiltInKeywordType{},
-	"CONFIG":                         BuiltInKeywordType{},
-	"CONNECTION":                     BuiltInKeywordType{},
-	"CONSISTENCY":                    BuiltInKeywordType{},
-	"CONSISTENT":                     BuiltInKeywordType{},
-	"CONTEXT":                        BuiltInKeywordType{},
-	"CPU":                            BuiltInKeywordType{},
-	"CSV_BACKSLASH_ESCAPE":           BuiltInKeywordType{},
-	"CSV_DELIMITER":                  BuiltInKeywordType{},
-	"CSV_HEADER":                     BuiltInKeywordType{},
-	"CSV_NOT_NULL":                   BuiltInKeywordType{},
-	"CSV_NULL":                       BuiltInKeywordType{},
-	"CSV_SEPARATOR":                  BuiltInKeywordType{},
-	"CSV_TRIM_LAST_SEPARATORS":       BuiltInKeywordType{},
-	"CURRENT":                        BuiltInKeywordType{},
-	"CYCLE":                          BuiltInKeywordType{},
-	"DATA":                           BuiltInKeywordType{},
-	"DATE":                           BuiltInKeywordType{},
-	"DATETIME":                       BuiltInKeywordType{},
-	"DAY":                            BuiltInKeywordType{},
-	"DEALLOCATE":                     BuiltInKeywordType{},
-	"DECLARE":                        BuiltInKeywordType{},
-	"DEFINER":                        BuiltInKeywordType{},
-	"DELAY_KEY_WRITE":                BuiltInKeywordType{},
-	"DIGEST":                         BuiltInKeywordType{},
-	"DIRECTORY":                      BuiltInKeywordType{},
-	"DISABLE":                        BuiltInKeywordType{},
-	"DISABLED":                       BuiltInKeywordType{},
-	"DISCARD":                        BuiltInKeywordType{},
-	"DISK":                           BuiltInKeywordType{},
-	"DO":                             BuiltInKeywordType{},
-	"DUPLICATE":                      BuiltInKeywordType{},
-	"DYNAMIC":                        BuiltInKeywordType{},
-	"ENABLE":                         BuiltInKeywordType{},
-	"ENABLED":                        BuiltInKeywordType{},
-	"ENCRYPTION":                     BuiltInKeywordType{},
-	"ENCRYPTION_KEYFILE":             BuiltInKeywordType{},
-	"ENCRYPTION_METHOD":              BuiltInKeywordType{},
-	"END":                            BuiltInKeywordType{},
-	"ENFORCED":                       BuiltInKeywordType{},
-	"ENGINE":                         BuiltInKeywordType{},
-	"ENGINES":                        BuiltInKeywordType{},
-	"ENGINE_ATTRIBUTE":               BuiltInKeywordType{},
-	"ENUM":                           BuiltInKeywordType{},
-	"ERROR":                          BuiltInKeywordType{},
-	"ERRORS":                         BuiltInKeywordType{},
-	"ESCAPE":                         BuiltInKeywordType{},
-	"EVENT":                          BuiltInKeywordType{},
-	"EVENTS":                         BuiltInKeywordType{},
-	"EVOLVE":                         BuiltInKeywordType{},
-	"EXCHANGE":                       BuiltInKeywordType{},
-	"EXCLUSIVE":                      BuiltInKeywordType{},
-	"EXECUTE":                        BuiltInKeywordType{},
-	"EXPANSION":                      BuiltInKeywordType{},
-	"EXPIRE":                         BuiltInKeywordType{},
-	"EXPLORE":                        BuiltInKeywordType{},
-	"EXTENDED":                       BuiltInKeywordType{},
-	"FAILED_LOGIN_ATTEMPTS":          BuiltInKeywordType{},
-	"FAULTS":                         BuiltInKeywordType{},
-	"FIELDS":                         BuiltInKeywordType{},
-	"FILE":                           BuiltInKeywordType{},
-	"FIRST":                          BuiltInKeywordType{},
-	"FIXED":                          BuiltInKeywordType{},
-	"FLUSH":                          BuiltInKeywordType{},
-	"FOLLOWING":                      BuiltInKeywordType{},
-	"FORMAT":                         BuiltInKeywordType{},
-	"FOUND":                          BuiltInKeywordType{},
-	"FULL":                           BuiltInKeywordType{},
-	"FUNCTION":                       BuiltInKeywordType{},
-	"GENERAL":                        BuiltInKeywordType{},
-	"GLOBAL":                         BuiltInKeywordType{},
-	"GRANTS":                         BuiltInKeywordType{},
-	"HANDLER":                        BuiltInKeywordType{},
-	"HASH":                           BuiltInKeywordType{},
-	"HELP":                           BuiltInKeywordType{},
-	"HISTOGRAM":                      BuiltInKeywordType{},
-	"HISTORY":                        BuiltInKeywordType{},
-	"HOSTS":                          BuiltInKeywordType{},
-	"HOUR":                           BuiltInKeywordType{},
-	"HYPO":                           BuiltInKeywordType{},
-	"IDENTIFIED":                     BuiltInKeywordType{},
-	"IGNORE_STATS":                   BuiltInKeywordType{},
-	"IMPORT":                         BuiltInKeywordType{},
-	"IMPORTS":                        BuiltInKeywordType{},
-	"INCREMENT":                      BuiltInKeywordType{},
-	"INCREMENTAL":                    BuiltInKeywordType{},
-	"INDEXES":                        BuiltInKeywordType{},
-	"INSERT_METHOD":                  BuiltInKeywordType{},
-	"INSTANCE":                       BuiltInKeywordType{},
-	"INVISIBLE":                      BuiltInKeywordType{},
-	"INVOKER":                        BuiltInKeywordType{},
-	"IO":                             BuiltInKeywordType{},
-	"IPC":                            BuiltInKeywordType{},
-	"ISOLATION":                      BuiltInKeywordType{},
-	"ISSUER":                         BuiltInKeywordType{},
-	"JSON":                           BuiltInKeywordType{},
-	"KEY_BLOCK_SIZE":                 BuiltInKeywordType{},
-	"LABELS":                         BuiltInKeywordType{},
-	"LANGUAGE":                       BuiltInKeywordType{},
-	"LAST":                           BuiltInKeywordType{},
-	"LASTVAL":                        BuiltInKeywordType{},
-	"LAST_BACKUP":                    BuiltInKeywordType{},
-	"LESS":                           BuiltInKeywordType{},
-	"LEVEL":                          BuiltInKeywordType{},
-	"LIST":                           BuiltInKeywordType{},
-	"LOAD_STATS":                     BuiltInKeywordType{},
-	"LOCAL":                          BuiltInKeywordType{},
-	"LOCATION":                       BuiltInKeywordType{},
-	"LOCKED":                         BuiltInKeywordType{},
-	"LOGS":                           BuiltInKeywordType{},
-	"MASTER":                         BuiltInKeywordType{},
-	"MAX_CONNECTIONS_PER_HOUR":       BuiltInKeywordType{},
-	"MAX_IDXNUM":                     BuiltInKeywordType{},
-	"MAX_MINUTES":                    BuiltInKeywordType{},
-	"MAX_QUERIES_PER_HOUR":           BuiltInKeywordType{},
-	"MAX_ROWS":                       BuiltInKeywordType{},
-	"MAX_UPDATES_PER_HOUR":           BuiltInKeywordType{},
-	"MAX_USER_CONNECTIONS":           BuiltInKeywordType{},
-	"MB":                             BuiltInKeywordType{},
-	"MEMBER":                         BuiltInKeywordType{},
-	"MEMORY":                         BuiltInKeywordType{},
-	"MERGE":                          BuiltInKeywordType{},
-	"MICROSECOND":                    BuiltInKeywordType{},
-	"MINUTE":                         BuiltInKeywordType{},
-	"MINVALUE":                       BuiltInKeywordType{},
-	"MIN_ROWS":                       BuiltInKeywordType{},
-	"MODE":                           BuiltInKeywordType{},
-	"MODIFY":                         BuiltInKeywordType{},
-	"MONTH":                          BuiltInKeywordType{},
-	"NAMES":                          BuiltInKeywordType{},
-	"NATIONAL":                       BuiltInKeywordType{},
-	"NCHAR":                          BuiltInKeywordType{},
-	"NEVER":                          BuiltInKeywordType{},
-	"NEXT":                           BuiltInKeywordType{},
-	"NEXTVAL":                        BuiltInKeywordType{},
-	"NO":                             BuiltInKeywordType{},
-	"NOCACHE":                        BuiltInKeywordType{},
-	"NOCYCLE":                        BuiltInKeywordType{},
-	"NODEGROUP":                      BuiltInKeywordType{},
-	"NOMAXVALUE":                     BuiltInKeywordType{},
-	"NOMINVALUE":                     BuiltInKeywordType{},
-	"NONCLUSTERED":                   BuiltInKeywordType{},
-	"NONE":                           BuiltInKeywordType{},
-	"NOWAIT":                         BuiltInKeywordType{},
-	"NULLS":                          BuiltInKeywordType{},
-	"NVARCHAR":                       BuiltInKeywordType{},
-	"OFF":                            BuiltInKeywordType{},
-	"OFFSET":                         BuiltInKeywordType{},
-	"OLTP_READ_ONLY":                 BuiltInKeywordType{},
-	"OLTP_READ_WRITE":                BuiltInKeywordType{},
-	"OLTP_WRITE_ONLY":                BuiltInKeywordType{},
-	"ONLINE":                         BuiltInKeywordType{},
-	"ONLY":                           BuiltInKeywordType{},
-	"ON_DUPLICATE":                   BuiltInKeywordType{},
-	"OPEN":                           BuiltInKeywordType{},
-	"OPTIONAL":                       BuiltInKeywordType{},
-	"PACK_KEYS":                      BuiltInKeywordType{},
-	"PAGE":                           BuiltInKeywordType{},
-	"PARSER":                         BuiltInKeywordType{},
-	"PARTIAL":                        BuiltInKeywordType{},
-	"PARTITIONING":                   BuiltInKeywordType{},
-	"PARTITIONS":                     BuiltInKeywordType{},
-	"PASSWORD":                       BuiltInKeywordType{},
-	"PASSWORD_LOCK_TIME":             BuiltInKeywordType{},
-	"PAUSE":                          BuiltInKeywordType{},
-	"PERCENT":                        BuiltInKeywordType{},
-	"PER_DB":                         BuiltInKeywordType{},
-	"PER_TABLE":                      BuiltInKeywordType{},
-	"PLUGINS":                        BuiltInKeywordType{},
-	"POINT":                          BuiltInKeywordType{},
-	"POLICY":                         BuiltInKeywordType{},
-	"PRECEDING":                      BuiltInKeywordType{},
-	"PREPARE":                        BuiltInKeywordType{},
-	"PRESERVE":                       BuiltInKeywordType{},
-	"PRE_SPLIT_REGIONS":              BuiltInKeywordType{},
-	"PRIVILEGES":                     BuiltInKeywordType{},
-	"PROCESS":                        BuiltInKeywordType{},
-	"PROCESSLIST":                    BuiltInKeywordType{},
-	"PROFILE":                        BuiltInKeywordType{},
-	"PROFILES":                       BuiltInKeywordType{},
-	"PROXY":                          BuiltInKeywordType{},
-	"PURGE":                          BuiltInKeywordType{},
-	"QUARTER":                        BuiltInKeywordType{},
-	"QUERIES":                        BuiltInKeywordType{},
-	"QUERY":                          BuiltInKeywordType{},
-	"QUICK":                          BuiltInKeywordType{},
-	"RATE_LIMIT":                     BuiltInKeywordType{},
-	"REBUILD":                        BuiltInKeywordType{},
-	"RECOMMEND":                      BuiltInKeywordType{},
-	"RECOVER":                        BuiltInKeywordType{},
-	"REDUNDANT":                      BuiltInKeywordType{},
-	"REFRESH":                        BuiltInKeywordType{},
-	"RELOAD":                         BuiltInKeywordType{},
-	"REMOVE":                         BuiltInKeywordType{},
-	"REORGANIZE":                     BuiltInKeywordType{},
-	"REPAIR":                         BuiltInKeywordType{},
-	"REPEATABLE":                     BuiltInKeywordType{},
-	"REPLICA":                        BuiltInKeywordType{},
-	"REPLICAS":                       BuiltInKeywordType{},
-	"REPLICATION":                    BuiltInKeywordType{},
-	"REQUIRED":                       BuiltInKeywordType{},
-	"RESOURCE":                       BuiltInKeywordType{},
-	"RESPECT":                        BuiltInKeywordType{},
-	"RESTART":                        BuiltInKeywordType{},
-	"RESTORE":                        BuiltInKeywordType{},
-	"RESTORES":                       BuiltInKeywordType{},
-	"RESUME":                         BuiltInKeywordType{},
-	"REUSE":                          BuiltInKeywordType{},
-	"REVERSE":                        BuiltInKeywordType{},
-	"ROLE":                           BuiltInKeywordType{},
-	"ROLLBACK":                       BuiltInKeywordType{},
-	"ROLLUP":                         BuiltInKeywordType{},
-	"ROUTINE":                        BuiltInKeywordType{},
-	"ROW_COUNT":                      BuiltInKeywordType{},
-	"ROW_FORMAT":                     BuiltInKeywordType{},
-	"RTREE":                          BuiltInKeywordType{},
-	"RULE":                           BuiltInKeywordType{},
-	"SAN":                            BuiltInKeywordType{},
-	"SAVEPOINT":                      BuiltInKeywordType{},
-	"SECOND":                         BuiltInKeywordType{},
-	"SECONDARY":                      BuiltInKeywordType{},
-	"SECONDARY_ENGINE":               BuiltInKeywordType{},
-	"SECONDARY_ENGINE_ATTRIBUTE":     BuiltInKeywordType{},
-	"SECONDARY_LOAD":                 BuiltInKeywordType{},
-	"SECONDARY_UNLOAD":               BuiltInKeywordType{},
-	"SECURITY":                       BuiltInKeywordType{},
-	"SEND_CREDENTIALS_TO_TIKV":       BuiltInKeywordType{},
-	"SEPARATOR":                      BuiltInKeywordType{},
-	"SEQUENCE":                       BuiltInKeywordType{},
-	"SERIAL":                         BuiltInKeywordType{},
-	"SERIALIZABLE":                   BuiltInKeywordType{},
-	"SESSION":                        BuiltInKeywordType{},
-	"SETVAL":                         BuiltInKeywordType{},
-	"SHARD_ROW_ID_BITS":              BuiltInKeywordType{},
-	"SHARE":                          BuiltInKeywordType{},
-	"SHARED":                         BuiltInKeywordType{},
-	"SHUTDOWN":                       BuiltInKeywordType{},
-	"SIGNED":                         BuiltInKeywordType{},
-	"SIMPLE":                         BuiltInKeywordType{},
-	"SKIP":                           BuiltInKeywordType{},
-	"SKIP_SCHEMA_FILES":              BuiltInKeywordType{},
-	"SLAVE":                          BuiltInKeywordType{},
-	"SLOW":                           BuiltInKeywordType{},
-	"SNAPSHOT":                       BuiltInKeywordType{},
-	"SOME":                           BuiltInKeywordType{},
-	"SOURCE":                         BuiltInKeywordType{},
-	"SQL_BUFFER_RESULT":              BuiltInKeywordType{},
-	"SQL_CACHE":                      BuiltInKeywordType{},
-	"SQL_NO_CACHE":                   BuiltInKeywordType{},
-	"SQL_TSI_DAY":                    BuiltInKeywordType{},
-	"SQL_TSI_HOUR":                   BuiltInKeywordType{},
-	"SQL_TSI_MINUTE":                 BuiltInKeywordType{},
-	"SQL_TSI_MONTH":                  BuiltInKeywordType{},
-	"SQL_TSI_QUARTER":                BuiltInKeywordType{},
-	"SQL_TSI_SECOND":                 BuiltInKeywordType{},
-	"SQL_TSI_WEEK":                   BuiltInKeywordType{},
-	"SQL_TSI_YEAR":                   BuiltInKeywordType{},
-	"START":                          BuiltInKeywordType{},
-	"STATS_AUTO_RECALC":              BuiltInKeywordType{},
-	"STATS_COL_CHOICE":               BuiltInKeywordType{},
-	"STATS_COL_LIST":                 BuiltInKeywordType{},
-	"STATS_OPTIONS":                  BuiltInKeywordType{},
-	"STATS_PERSISTENT":               BuiltInKeywordType{},
-	"STATS_SAMPLE_PAGES":             BuiltInKeywordType{},
-	"STATS_SAMPLE_RATE":              BuiltInKeywordType{},
-	"STATUS":                         BuiltInKeywordType{},
-	"STORAGE":                        BuiltInKeywordType{},
-	"STRICT_FORMAT":                  BuiltInKeywordType{},
-	"SUBJECT":                        BuiltInKeywordType{},
-	"SUBPARTITION":                   BuiltInKeywordType{},
-	"SUBPARTITIONS":                  BuiltInKeywordType{},
-	"SUPER":                          BuiltInKeywordType{},
-	"SWAPS":                          BuiltInKeywordType{},
-	"SWITCHES":                       BuiltInKeywordType{},
-	"SYSTEM":                         BuiltInKeywordType{},
-	"SYSTEM_TIME":                    BuiltInKeywordType{},
-	"TABLES":                         BuiltInKeywordType{},
-	"TABLESPACE":                     BuiltInKeywordType{},
-	"TABLE_CHECKSUM":                 BuiltInKeywordType{},
-	"TEMPORARY":                      BuiltInKeywordType{},
-	"TEMPTABLE":                      BuiltInKeywordType{},
-	"TEXT":                           BuiltInKeywordType{},
-	"THAN":                           BuiltInKeywordType{},
-	"TIKV_IMPORTER":                  BuiltInKeywordType{},
-	"TIME":                           BuiltInKeywordType{},
-	"TIMEOUT":                        BuiltInKeywordType{},
-	"TIMESTAMP":                      BuiltInKeywordType{},
-	"TOKEN_ISSUER":                   BuiltInKeywordType{},
-	"TPCC":                           BuiltInKeywordType{},
-	"TPCH_10":                        BuiltInKeywordType{},
-	"TRACE":                          BuiltInKeywordType{},
-	"TRADITIONAL":                    BuiltInKeywordType{},
-	"TRANSACTION":                    BuiltInKeywordType{},
-	"TRIGGERS":                       BuiltInKeywordType{},
-	"TRUNCATE":                       BuiltInKeywordType{},
-	"TSO":                            BuiltInKeywordType{},
-	"TTL":                            BuiltInKeywordType{},
-	"TTL_ENABLE":                     BuiltInKeywordType{},
-	"TTL_JOB_INTERVAL":               BuiltInKeywordType{},
-	"TYPE":                           BuiltInKeywordType{},
-	"UNBOUNDED":                      BuiltInKeywordType{},
-	"UNCOMMITTED":                    BuiltInKeywordType{},
-	"UNDEFINED":                      BuiltInKeywordType{},
-	"UNICODE":                        BuiltInKeywordType{},
-	"UNKNOWN":                        BuiltInKeywordType{},
-	"UNSET":                          BuiltInKeywordType{},
-	"USER":                           BuiltInKeywordType{},
-	"VALIDATION":                     BuiltInKeywordType{},
-	"VALUE":                          BuiltInKeywordType{},
-	"VARIABLES":                      BuiltInKeywordType{},
-	"VECTOR":                         BuiltInKeywordType{},
-	"VIEW":                           BuiltInKeywordType{},
-	"VISIBLE":                        BuiltInKeywordType{},
-	"WAIT":                           BuiltInKeywordType{},
-	"WAIT_TIFLASH_READY":             BuiltInKeywordType{},
-	"WARNINGS":                       BuiltInKeywordType{},
-	"WEEK":                           BuiltInKeywordType{},
-	"WEIGHT_STRING":                  BuiltInKeywordType{},
-	"WITHOUT":                        BuiltInKeywordType{},
-	"WITH_SYS_TABLE":                 BuiltInKeywordType{},
-	"WORKLOAD":                       BuiltInKeywordType{},
-	"X509":                           BuiltInKeywordType{},
-	"YEAR":                           BuiltInKeywordType{},
-	"ADMIN":                          BuiltInKeywordType{},
-	"BATCH":                          BuiltInKeywordType{},
-	"BUCKETS":                        BuiltInKeywordType{},
-	"BUILTINS":                       BuiltInKeywordType{},
-	"CANCEL":                         BuiltInKeywordType{},
-	"CARDINALITY":                    BuiltInKeywordType{},
-	"CMSKETCH":                       BuiltInKeywordType{},
-	"COLUMN_STATS_USAGE":             BuiltInKeywordType{},
-	"CORRELATION":                    BuiltInKeywordType{},
-	"DDL":                            BuiltInKeywordType{},
-	"DEPENDENCY":                     BuiltInKeywordType{},
-	"DEPTH":                          BuiltInKeywordType{},
-	"DISTRIBUTE":                     BuiltInKeywordType{},
-	"DISTRIBUTION":                   BuiltInKeywordType{},
-	"DISTRIBUTIONS":                  BuiltInKeywordType{},
-	"DRY":                            BuiltInKeywordType{},
-	"HISTOGRAMS_IN_FLIGHT":           BuiltInKeywordType{},
-	"JOB":                            BuiltInKeywordType{},
-	"JOBS":                           BuiltInKeywordType{},
-	"NODE_ID":                        BuiltInKeywordType{},
-	"NODE_STATE":                     BuiltInKeywordType{},
-	"OPTIMISTIC":                     BuiltInKeywordType{},
-	"PESSIMISTIC":                    BuiltInKeywordType{},
-	"REGION":                         BuiltInKeywordType{},
-	"REGIONS":                        BuiltInKeywordType{},
-	"RESET":                          BuiltInKeywordType{},
-	"RUN":                            BuiltInKeywordType{},
-	"SAMPLERATE":                     BuiltInKeywordType{},
-	"SAMPLES":                        BuiltInKeywordType{},
-	"SESSION_STATES":                 BuiltInKeywordType{},
-	"SPLIT":                          BuiltInKeywordType{},
-	"STATISTICS":                     BuiltInKeywordType{},
-	"STATS":                          BuiltInKeywordType{},
-	"STATS_BUCKETS":                  BuiltInKeywordType{},
-	"STATS_EXTENDED":                 BuiltInKeywordType{},
-	"STATS_HEALTHY":                  BuiltInKeywordType{},
-	"STATS_HISTOGRAMS":               BuiltInKeywordType{},
-	"STATS_LOCKED":                   BuiltInKeywordType{},
-	"STATS_META":                     BuiltInKeywordType{},
-	"STATS_TOPN":                     BuiltInKeywordType{},
-	"TIDB":                           BuiltInKeywordType{},
-	"TIFLASH":                        BuiltInKeywordType{},
-	"TOPN":                           BuiltInKeywordType{},
-	"WIDTH":                          BuiltInKeywordType{},
+// IsReservedKeyword reports whether word, in any case, is a reserved
+// keyword of dialect, one that must be quoted to be used as a name.
+func IsReservedKeyword(word []byte, dialect Dialect) bool {
+	k, ok := keywords.lookupHash(word, hashFold(word))
+	return ok && k.reserved.has(dialect)
 }
 
-func isBuiltInKeyword(s []byte) (BuiltInKeywordType, bool) {
-	typ, ok := _builtinnKeywords[string(s)]
-	return typ, ok
+// isBuiltInKeyword reports whether s, in any case, is a keyword of
+// dialect. h is hashFold(s). The function attributes are returned even when s is not a
+// keyword, as function names are only known by the `(` following them.
+func isBuiltInKeyword(s []byte, h uint64, dialect Dialect) (BuiltInKeywordType, bool) {
+	k, ok := keywords.lookupHash(s, h)
+	if !ok {
+		return BuiltInKeywordType{}, false
+	}
+	if !k.dialects.has(dialect) {
+		return BuiltInKeywordType{Attr: k.attr & keywordAttrFunction}, false
+	}
+	attr := k.attr
+	if k.reserved.has(dialect) {
+		attr |= KeywordAttrReserved
+	}
+	return BuiltInKeywordType{Attr: attr}, true
 }
@@ -0,0 +1,159 @@
+package lexer
+
+// Source: https://dev.mysql.com/doc/refman/8.0/en/built-in-function-reference.html
+//
+// VALUES() is left out, as it cannot be told apart from `VALUES(1, 2)` in
+// INSERT statements.
+
+var builtinFunctions = concat(
+	functionsString,
+	functionsNumeric,
+	functionsTemporal,
+	functionsControl,
+	functionsInfo,
+	functionsCrypto,
+	functionsMisc,
+	functionsJSON,
+	functionsOther,
+	functionsSpatial,
+)
+
+// String functions.
+var functionsString = []string{
+	"ASCII", "BIN", "BIT_LENGTH", "CHAR", "CHARACTER_LENGTH",
+	"CHAR_LENGTH", "CONCAT", "CONCAT_WS", "ELT", "EXPORT_SET", "FIELD",
+	"FIND_IN_SET", "FORMAT", "FROM_BASE64", "HEX", "INSERT", "INSTR",
+	"LCASE", "LEFT", "LENGTH", "LOAD_FILE", "LOCATE", "LOWER", "LPAD",
+	"LTRIM", "MAKE_SET", "MID", "OCT", "OCTET_LENGTH", "ORD", "POSITION",
+	"QUOTE", "REGEXP_INSTR", "REGEXP_LIKE", "REGEXP_REPLACE",
+	"REGEXP_SUBSTR", "REPEAT", "REPLACE", "REVERSE", "RIGHT", "RPAD",
+	"RTRIM", "SOUNDEX", "SPACE", "STRCMP", "SUBSTR", "SUBSTRING",
+	"SUBSTRING_INDEX", "TO_BASE64", "TRIM", "UCASE", "UNHEX", "UPPER",
+	"WEIGHT_STRING",
+}
+
+// Numeric functions.
+var functionsNumeric = []string{
+	"ABS", "ACOS", "ASIN", "ATAN", "ATAN2", "BIT_COUNT", "CEIL",
+	"CEILING", "CONV", "COS", "COT", "CRC32", "DEGREES", "EXP", "FLOOR",
+	"LN", "LOG", "LOG10", "LOG2", "MOD", "PI", "POW", "POWER", "RADIANS",
+	"RAND", "ROUND", "SIGN", "SIN", "SQRT", "TAN", "TRUNCATE",
+}
+
+// Date and time functions.
+var functionsTemporal = []string{
+	"ADDDATE", "ADDTIME", "CONVERT_TZ", "CURDATE", "CURRENT_DATE",
+	"CURRENT_TIME", "CURRENT_TIMESTAMP", "CURTIME", "DATE", "DATEDIFF",
+	"DATE_ADD", "DATE_FORMAT", "DATE_SUB", "DAY", "DAYNAME", "DAYOFMONTH",
+	"DAYOFWEEK", "DAYOFYEAR", "EXTRACT", "FROM_DAYS", "FROM_UNIXTIME",
+	"GET_FORMAT", "HOUR", "LAST_DAY", "LOCALTIME", "LOCALTIMESTAMP",
+	"MAKEDATE", "MAKETIME", "MICROSECOND", "MINUTE", "MONTH", "MONTHNAME",
+	"NOW", "PERIOD_ADD", "PERIOD_DIFF", "QUARTER", "SECOND",
+	"SEC_TO_TIME", "STR_TO_DATE", "SUBDATE", "SUBTIME", "SYSDATE", "TIME",
+	"TIMEDIFF", "TIMESTAMP", "TIMESTAMPADD", "TIMESTAMPDIFF",
+	"TIME_FORMAT", "TIME_TO_SEC", "TO_DAYS", "TO_SECONDS",
+	"UNIX_TIMESTAMP", "UTC_DATE", "UTC_TIME", "UTC_TIMESTAMP", "WEEK",
+	"WEEKDAY", "WEEKOFYEAR", "YEAR", "YEARWEEK",
+}
+
+// Cast, flow control and comparison functions.
+var functionsControl = []string{
+	"CAST", "COALESCE", "CONVERT", "GREATEST", "IF", "IFNULL", "INTERVAL",
+	"ISNULL", "LEAST", "NULLIF",
+}
+
+// Information functions.
+var functionsInfo = []string{
+	"BENCHMARK", "CHARSET", "COERCIBILITY", "COLLATION", "CONNECTION_ID",
+	"CURRENT_ROLE", "CURRENT_USER", "DATABASE", "FOUND_ROWS",
+	"ICU_VERSION", "LAST_INSERT_ID", "ROLES_GRAPHML", "ROW_COUNT",
+	"SCHEMA", "SESSION_USER", "SYSTEM_USER", "USER", "VERSION",
+}
+
+// Encryption and compression functions. DECODE, DES_*, ENCODE, ENCRYPT
+// and PASSWORD were removed in 8.0.
+var functionsCrypto = []string{
+	"AES_DECRYPT", "AES_ENCRYPT", "COMPRESS", "DECODE", "DES_DECRYPT",
+	"DES_ENCRYPT", "ENCODE", "ENCRYPT", "MD5", "PASSWORD", "RANDOM_BYTES",
+	"SHA", "SHA1", "SHA2", "STATEMENT_DIGEST", "STATEMENT_DIGEST_TEXT",
+	"UNCOMPRESS", "UNCOMPRESSED_LENGTH", "VALIDATE_PASSWORD_STRENGTH",
+}
+
+// Locking, replication and miscellaneous functions.
+var functionsMisc = []string{
+	"ANY_VALUE", "BIN_TO_UUID", "DEFAULT", "GET_LOCK", "GROUPING",
+	"GTID_SUBSET", "GTID_SUBTRACT", "INET6_ATON", "INET6_NTOA",
+	"INET_ATON", "INET_NTOA", "IS_FREE_LOCK", "IS_IPV4", "IS_IPV4_COMPAT",
+	"IS_IPV4_MAPPED", "IS_IPV6", "IS_USED_LOCK", "IS_UUID",
+	"MASTER_POS_WAIT", "NAME_CONST", "RELEASE_ALL_LOCKS", "RELEASE_LOCK",
+	"SLEEP", "SOURCE_POS_WAIT", "UUID", "UUID_SHORT", "UUID_TO_BIN",
+	"WAIT_FOR_EXECUTED_GTID_SET",
+}
+
+// JSON functions.
+var functionsJSON = []string{
+	"JSON_ARRAY", "JSON_ARRAY_APPEND", "JSON_ARRAY_INSERT",
+	"JSON_CONTAINS", "JSON_CONTAINS_PATH", "JSON_DEPTH", "JSON_EXTRACT",
+	"JSON_INSERT", "JSON_KEYS", "JSON_LENGTH", "JSON_MERGE",
+	"JSON_MERGE_PATCH", "JSON_MERGE_PRESERVE", "JSON_OBJECT",
+	"JSON_OVERLAPS", "JSON_PRETTY", "JSON_QUOTE", "JSON_REMOVE",
+	"JSON_REPLACE", "JSON_SCHEMA_VALID", "JSON_SCHEMA_VALIDATION_REPORT",
+	"JSON_SEARCH", "JSON_SET", "JSON_STORAGE_FREE", "JSON_STORAGE_SIZE",
+	"JSON_TYPE", "JSON_UNQUOTE", "JSON_VALID", "JSON_VALUE",
+}
+
+// XML, full-text and performance schema functions.
+var functionsOther = []string{
+	"EXTRACTVALUE", "FORMAT_BYTES", "FORMAT_PICO_TIME", "MATCH",
+	"PS_CURRENT_THREAD_ID", "PS_THREAD_ID", "UPDATEXML",
+}
+
+// Spatial functions.
+var functionsSpatial = []string{
+	"GEOMCOLLECTION", "GEOMETRYCOLLECTION", "LINESTRING", "MBRCONTAINS",
+	"MBRCOVEREDBY", "MBRCOVERS", "MBRDISJOINT", "MBREQUALS",
+	"MBRINTERSECTS", "MBROVERLAPS", "MBRTOUCHES", "MBRWITHIN",
+	"MULTILINESTRING", "MULTIPOINT", "MULTIPOLYGON", "POINT", "POLYGON",
+	"ST_AREA", "ST_ASBINARY", "ST_ASGEOJSON", "ST_ASTEXT", "ST_ASWKB",
+	"ST_ASWKT", "ST_BUFFER", "ST_BUFFER_STRATEGY", "ST_CENTROID",
+	"ST_COLLECT", "ST_CONTAINS", "ST_CONVEXHULL", "ST_CROSSES",
+	"ST_DIFFERENCE", "ST_DIMENSION", "ST_DISJOINT", "ST_DISTANCE",
+	"ST_DISTANCE_SPHERE", "ST_ENDPOINT", "ST_ENVELOPE", "ST_EQUALS",
+	"ST_EXTERIORRING", "ST_FRECHETDISTANCE", "ST_GEOHASH",
+	"ST_GEOMCOLLFROMTEXT", "ST_GEOMCOLLFROMTXT", "ST_GEOMCOLLFROMWKB",
+	"ST_GEOMETRYCOLLECTIONFROMTEXT", "ST_GEOMETRYCOLLECTIONFROMWKB",
+	"ST_GEOMETRYFROMTEXT", "ST_GEOMETRYFROMWKB", "ST_GEOMETRYN",
+	"ST_GEOMETRYTYPE", "ST_GEOMFROMGEOJSON", "ST_GEOMFROMTEXT",
+	"ST_GEOMFROMWKB", "ST_HAUSDORFFDISTANCE", "ST_INTERIORRINGN",
+	"ST_INTERSECTION", "ST_INTERSECTS", "ST_ISCLOSED", "ST_ISEMPTY",
+	"ST_ISSIMPLE", "ST_ISVALID", "ST_LATFROMGEOHASH", "ST_LATITUDE",
+	"ST_LENGTH", "ST_LINEFROMTEXT", "ST_LINEFROMWKB",
+	"ST_LINEINTERPOLATEPOINT", "ST_LINEINTERPOLATEPOINTS",
+	"ST_LINESTRINGFROMTEXT", "ST_LINESTRINGFROMWKB", "ST_LONGFROMGEOHASH",
+	"ST_LONGITUDE", "ST_MAKEENVELOPE", "ST_MLINEFROMTEXT",
+	"ST_MLINEFROMWKB", "ST_MPOINTFROMTEXT", "ST_MPOINTFROMWKB",
+	"ST_MPOLYFROMTEXT", "ST_MPOLYFROMWKB", "ST_MULTILINESTRINGFROMTEXT",
+	"ST_MULTILINESTRINGFROMWKB", "ST_MULTIPOINTFROMTEXT",
+	"ST_MULTIPOINTFROMWKB", "ST_MULTIPOLYGONFROMTEXT",
+	"ST_MULTIPOLYGONFROMWKB", "ST_NUMGEOMETRIES", "ST_NUMINTERIORRING",
+	"ST_NUMINTERIORRINGS", "ST_NUMPOINTS", "ST_OVERLAPS",
+	"ST_POINTATDISTANCE", "ST_POINTFROMGEOHASH", "ST_POINTFROMTEXT",
+	"ST_POINTFROMWKB", "ST_POINTN", "ST_POLYFROMTEXT", "ST_POLYFROMWKB",
+	"ST_POLYGONFROMTEXT", "ST_POLYGONFROMWKB", "ST_SIMPLIFY", "ST_SRID",
+	"ST_STARTPOINT", "ST_SWAPXY", "ST_SYMDIFFERENCE", "ST_TOUCHES",
+	"ST_TRANSFORM", "ST_UNION", "ST_VALIDATE", "ST_WITHIN", "ST_X",
+	"ST_Y",
+}
+
+// Aggregate functions, also usable as window functions.
+var aggregateFunctions = []string{
+	"AVG", "BIT_AND", "BIT_OR", "BIT_XOR", "COUNT", "GROUP_CONCAT",
+	"JSON_ARRAYAGG", "JSON_OBJECTAGG", "MAX", "MIN", "STD", "STDDEV",
+	"STDDEV_POP", "STDDEV_SAMP", "SUM", "VARIANCE", "VAR_POP", "VAR_SAMP",
+}
+
+// Functions only usable with an OVER clause.
+var windowFunctions = []string{
+	"CUME_DIST", "DENSE_RANK", "FIRST_VALUE", "LAG", "LAST_VALUE", "LEAD",
+	"NTH_VALUE", "NTILE", "PERCENT_RANK", "RANK", "ROW_NUMBER",
+}
@@ -0,0 +1,142 @@
+package lexer
+
+import (
+	"sort"
+
+	"github.com/bagaswh/mysql-toolkit/pkg/bytes"
+)
+
+// keywordHash is a minimal perfect hash over the keyword table, built
+// once at init. The hash of a word picks a bucket, and the seed stored
+// for that bucket places each of its words in a distinct slot. Words are
+// hashed case-insensitively as they are read, so lookups need neither a
+// copy of the input nor a map. Compared with a map keyed by the upper
+// cased word, this takes about a tenth off lexing keyword heavy queries.
+type keywordHash struct {
+	seeds  []uint32
+	slots  []keywordSlot
+	maxLen int
+}
+
+type keywordSlot struct {
+	word string
+	keyword
+}
+
+const (
+	fnvOffset = 14695981039346656037
+	fnvPrime  = 1099511628211
+)
+
+func hashFold(s []byte) uint64 {
+	h := uint64(fnvOffset)
+	for _, c := range s {
+		h = hashFoldByte(h, c)
+	}
+	return h
+}
+
+func hashFoldByte(h uint64, c byte) uint64 {
+	return (h ^ uint64(bytes.ToUpperByte(c))) * fnvPrime
+}
+
+// reduce maps h to [0, n) without a division.
+func reduce(h uint64, n int) int {
+	return int((h >> 32) * uint64(n) >> 32)
+}
+
+func slotHash(h uint64, seed uint32) uint64 {
+	h ^= uint64(seed) * 0x9e3779b97f4a7c15
+	h ^= h >> 33
+	h *= 0xff51afd7ed558ccd
+	h ^= h >> 33
+	return h
+}
+
+func newKeywordHash(m map[string]keyword) *keywordHash {
+	words := make([]string, 0, len(m))
+	for w := range m {
+		words = append(words, w)
+	}
+	sort.Strings(words)
+
+	n := len(words)
+	kh := &keywordHash{
+		seeds: make([]uint32, max(n/2, 1)),
+		slots: make([]keywordSlot, n),
+	}
+
+	buckets := make([][]int, len(kh.seeds))
+	hashes := make([]uint64, n)
+	for i, w := range words {
+		hashes[i] = hashFold([]byte(w))
+		b := reduce(hashes[i], len(buckets))
+		buckets[b] = append(buckets[b], i)
+		kh.maxLen = max(kh.maxLen, len(w))
+	}
+
+	// place the largest buckets first, while most slots are free
+	order := make([]int, len(buckets))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(i, j int) bool {
+		return len(buckets[order[i]]) > len(buckets[order[j]])
+	})
+
+	used := make([]bool, n)
+	slots := make([]int, 0, 8)
+	for _, b := range order {
+		if len(buckets[b]) == 0 {
+			break
+		}
+	seed:
+		for seed := uint32(1); ; seed++ {
+			if seed == 1<<24 {
+				panic("lexer: cannot build keyword hash")
+			}
+			slots = slots[:0]
+			for _, i := range buckets[b] {
+				slot := reduce(slotHash(hashes[i], seed), n)
+				if used[slot] {
+					continue seed
+				}
+				for _, s := range slots {
+					if s == slot {
+						continue seed
+					}
+				}
+				slots = append(slots, slot)
+			}
+			for j, i := range buckets[b] {
+				used[slots[j]] = true
+				kh.slots[slots[j]] = keywordSlot{words[i], m[words[i]]}
+			}
+			kh.seeds[b] = seed
+			break
+		}
+	}
+	return kh
+}
+
+func (kh *keywordHash) lookup(s []byte) (keyword, bool) {
+	return kh.lookupHash(s, hashFold(s))
+}
+
+// lookupHash is lookup for callers that computed hashFold(s) already.
+func (kh *keywordHash) lookupHash(s []byte, h uint64) (keyword, bool) {
+	if len(s) == 0 || len(s) > kh.maxLen {
+		return keyword{}, false
+	}
+	seed := kh.seeds[reduce(h, len(kh.seeds))]
+	slot := &kh.slots[reduce(slotHash(h, seed), len(kh.slots))]
+	if len(slot.word) != len(s) {
+		return keyword{}, false
+	}
+	for i := range s {
+		if bytes.ToUpperByte(s[i]) != slot.word[i] {
+			return keyword{}, false
+		}
+	}
+	return slot.keyword, true
+}
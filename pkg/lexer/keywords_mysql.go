@@ -0,0 +1,197 @@
+package lexer
+
+// Source: https://dev.mysql.com/doc/refman/8.0/en/keywords.html, and the 5.7
+// and 8.4 editions of the same page. Lists reflect the latest release of
+// each series.
+
+// Reserved in every MySQL version.
+var mysqlReserved = []string{
+	"ACCESSIBLE", "ADD", "ALL", "ALTER", "ANALYZE", "AND", "AS", "ASC",
+	"ASENSITIVE", "BEFORE", "BETWEEN", "BIGINT", "BINARY", "BLOB", "BOTH",
+	"BY", "CALL", "CASCADE", "CASE", "CHANGE", "CHAR", "CHARACTER",
+	"CHECK", "COLLATE", "COLUMN", "CONDITION", "CONSTRAINT", "CONTINUE",
+	"CONVERT", "CREATE", "CROSS", "CURRENT_DATE", "CURRENT_TIME",
+	"CURRENT_TIMESTAMP", "CURRENT_USER", "CURSOR", "DATABASE",
+	"DATABASES", "DAY_HOUR", "DAY_MICROSECOND", "DAY_MINUTE",
+	"DAY_SECOND", "DEC", "DECIMAL", "DECLARE", "DEFAULT", "DELAYED",
+	"DELETE", "DESC", "DESCRIBE", "DETERMINISTIC", "DISTINCT",
+	"DISTINCTROW", "DIV", "DOUBLE", "DROP", "DUAL", "EACH", "ELSE",
+	"ELSEIF", "ENCLOSED", "ESCAPED", "EXISTS", "EXIT", "EXPLAIN", "FALSE",
+	"FETCH", "FLOAT", "FLOAT4", "FLOAT8", "FOR", "FORCE", "FOREIGN",
+	"FROM", "FULLTEXT", "GENERATED", "GET", "GRANT", "GROUP", "HAVING",
+	"HIGH_PRIORITY", "HOUR_MICROSECOND", "HOUR_MINUTE", "HOUR_SECOND",
+	"IF", "IGNORE", "IN", "INDEX", "INFILE", "INNER", "INOUT",
+	"INSENSITIVE", "INSERT", "INT", "INT1", "INT2", "INT3", "INT4",
+	"INT8", "INTEGER", "INTERVAL", "INTO", "IO_AFTER_GTIDS",
+	"IO_BEFORE_GTIDS", "IS", "ITERATE", "JOIN", "KEY", "KEYS", "KILL",
+	"LEADING", "LEAVE", "LEFT", "LIKE", "LIMIT", "LINEAR", "LINES",
+	"LOAD", "LOCALTIME", "LOCALTIMESTAMP", "LOCK", "LONG", "LONGBLOB",
+	"LONGTEXT", "LOOP", "LOW_PRIORITY", "MATCH", "MAXVALUE", "MEDIUMBLOB",
+	"MEDIUMINT", "MEDIUMTEXT", "MIDDLEINT", "MINUTE_MICROSECOND",
+	"MINUTE_SECOND", "MOD", "MODIFIES", "NATURAL", "NOT",
+	"NO_WRITE_TO_BINLOG", "NULL", "NUMERIC", "ON", "OPTIMIZE",
+	"OPTIMIZER_COSTS", "OPTION", "OPTIONALLY", "OR", "ORDER", "OUT",
+	"OUTER", "OUTFILE", "PARTITION", "PRECISION", "PRIMARY", "PROCEDURE",
+	"PURGE", "RANGE", "READ", "READS", "READ_WRITE", "REAL", "REFERENCES",
+	"REGEXP", "RELEASE", "RENAME", "REPEAT", "REPLACE", "REQUIRE",
+	"RESIGNAL", "RESTRICT", "RETURN", "REVOKE", "RIGHT", "RLIKE",
+	"SCHEMA", "SCHEMAS", "SECOND_MICROSECOND", "SELECT", "SENSITIVE",
+	"SEPARATOR", "SET", "SHOW", "SIGNAL", "SMALLINT", "SPATIAL",
+	"SPECIFIC", "SQL", "SQLEXCEPTION", "SQLSTATE", "SQLWARNING",
+	"SQL_BIG_RESULT", "SQL_CALC_FOUND_ROWS", "SQL_SMALL_RESULT", "SSL",
+	"STARTING", "STORED", "STRAIGHT_JOIN", "TABLE", "TERMINATED", "THEN",
+	"TINYBLOB", "TINYINT", "TINYTEXT", "TO", "TRAILING", "TRIGGER",
+	"TRUE", "UNDO", "UNION", "UNIQUE", "UNLOCK", "UNSIGNED", "UPDATE",
+	"USAGE", "USE", "USING", "UTC_DATE", "UTC_TIME", "UTC_TIMESTAMP",
+	"VALUES", "VARBINARY", "VARCHAR", "VARCHARACTER", "VARYING",
+	"VIRTUAL", "WHEN", "WHERE", "WHILE", "WITH", "WRITE", "XOR",
+	"YEAR_MONTH", "ZEROFILL",
+}
+
+// Reserved since 8.0. FUNCTION, ROW and ROWS were non-reserved in 5.7.
+var mysql80Reserved = []string{
+	"ARRAY", "CUBE", "CUME_DIST", "DENSE_RANK", "EMPTY", "EXCEPT",
+	"FIRST_VALUE", "FUNCTION", "GROUPING", "GROUPS", "INTERSECT",
+	"JSON_TABLE", "LAG", "LAST_VALUE", "LATERAL", "LEAD", "MEMBER",
+	"NTH_VALUE", "NTILE", "OF", "OVER", "PERCENT_RANK", "RANK",
+	"RECURSIVE", "ROW", "ROWS", "ROW_NUMBER", "SYSTEM", "WINDOW",
+}
+
+// Reserved since 8.4.
+var mysql84Reserved = []string{
+	"MANUAL", "PARALLEL", "QUALIFY", "TABLESAMPLE",
+}
+
+// Reserved until 8.4, which removed the MASTER_* replication options.
+var mysqlMasterReserved = []string{
+	"MASTER_BIND", "MASTER_SSL_VERIFY_SERVER_CERT",
+}
+
+// Non-reserved in every MySQL version.
+var mysqlKeywords = []string{
+	"ACCOUNT", "ACTION", "AFTER", "AGAINST", "AGGREGATE", "ALGORITHM",
+	"ALWAYS", "ANY", "ASCII", "AT", "AUTOEXTEND_SIZE", "AUTO_INCREMENT",
+	"AVG", "AVG_ROW_LENGTH", "BACKUP", "BEGIN", "BINLOG", "BIT", "BLOCK",
+	"BOOL", "BOOLEAN", "BTREE", "BYTE", "CACHE", "CASCADED",
+	"CATALOG_NAME", "CHAIN", "CHANGED", "CHANNEL", "CHARSET", "CHECKSUM",
+	"CIPHER", "CLASS_ORIGIN", "CLIENT", "CLOSE", "COALESCE", "CODE",
+	"COLLATION", "COLUMNS", "COLUMN_FORMAT", "COLUMN_NAME", "COMMENT",
+	"COMMIT", "COMMITTED", "COMPACT", "COMPLETION", "COMPRESSED",
+	"COMPRESSION", "CONCURRENT", "CONNECTION", "CONSISTENT",
+	"CONSTRAINT_CATALOG", "CONSTRAINT_NAME", "CONSTRAINT_SCHEMA",
+	"CONTAINS", "CONTEXT", "CPU", "CURRENT", "CURSOR_NAME", "DATA",
+	"DATAFILE", "DATE", "DATETIME", "DAY", "DEALLOCATE", "DEFAULT_AUTH",
+	"DEFINER", "DELAY_KEY_WRITE", "DIAGNOSTICS", "DIRECTORY", "DISABLE",
+	"DISCARD", "DISK", "DO", "DUMPFILE", "DUPLICATE", "DYNAMIC", "ENABLE",
+	"ENCRYPTION", "END", "ENDS", "ENGINE", "ENGINES", "ENUM", "ERROR",
+	"ERRORS", "ESCAPE", "EVENT", "EVENTS", "EVERY", "EXCHANGE", "EXECUTE",
+	"EXPANSION", "EXPIRE", "EXPORT", "EXTENDED", "EXTENT_SIZE", "FAST",
+	"FAULTS", "FIELDS", "FILE", "FILE_BLOCK_SIZE", "FILTER", "FIRST",
+	"FIXED", "FLUSH", "FOLLOWS", "FORMAT", "FOUND", "FULL", "GENERAL",
+	"GEOMETRY", "GEOMETRYCOLLECTION", "GET_FORMAT", "GLOBAL", "GRANTS",
+	"GROUP_REPLICATION", "HANDLER", "HASH", "HELP", "HOST", "HOSTS",
+	"HOUR", "IDENTIFIED", "IGNORE_SERVER_IDS", "IMPORT", "INDEXES",
+	"INITIAL_SIZE", "INSERT_METHOD", "INSTALL", "INSTANCE", "INVOKER",
+	"IO", "IO_THREAD", "IPC", "ISOLATION", "ISSUER", "JSON",
+	"KEY_BLOCK_SIZE", "LANGUAGE", "LAST", "LEAVES", "LESS", "LEVEL",
+	"LINESTRING", "LIST", "LOCAL", "LOCKS", "LOGFILE", "LOGS", "MASTER",
+	"MAX_CONNECTIONS_PER_HOUR", "MAX_QUERIES_PER_HOUR", "MAX_ROWS",
+	"MAX_SIZE", "MAX_UPDATES_PER_HOUR", "MAX_USER_CONNECTIONS", "MEDIUM",
+	"MEMORY", "MERGE", "MESSAGE_TEXT", "MICROSECOND", "MIGRATE", "MINUTE",
+	"MIN_ROWS", "MODE", "MODIFY", "MONTH", "MULTILINESTRING",
+	"MULTIPOINT", "MULTIPOLYGON", "MUTEX", "MYSQL_ERRNO", "NAME", "NAMES",
+	"NATIONAL", "NCHAR", "NDB", "NDBCLUSTER", "NEVER", "NEW", "NEXT",
+	"NO", "NODEGROUP", "NONE", "NO_WAIT", "NUMBER", "NVARCHAR", "OFFSET",
+	"ONE", "ONLY", "OPEN", "OPTIONS", "OWNER", "PACK_KEYS", "PAGE",
+	"PARSER", "PARTIAL", "PARTITIONING", "PARTITIONS", "PASSWORD",
+	"PHASE", "PLUGIN", "PLUGINS", "PLUGIN_DIR", "POINT", "POLYGON",
+	"PORT", "PRECEDES", "PREPARE", "PRESERVE", "PREV", "PRIVILEGES",
+	"PROCESS", "PROCESSLIST", "PROFILE", "PROFILES", "PROXY", "QUARTER",
+	"QUERY", "QUICK", "READ_ONLY", "REBUILD", "RECOVER",
+	"REDO_BUFFER_SIZE", "REDUNDANT", "RELAY", "RELAYLOG",
+	"RELAY_LOG_FILE", "RELAY_LOG_POS", "RELAY_THREAD", "RELOAD", "REMOVE",
+	"REORGANIZE", "REPAIR", "REPEATABLE", "REPLICATE_DO_DB",
+	"REPLICATE_DO_TABLE", "REPLICATE_IGNORE_DB", "REPLICATE_IGNORE_TABLE",
+	"REPLICATE_REWRITE_DB", "REPLICATE_WILD_DO_TABLE",
+	"REPLICATE_WILD_IGNORE_TABLE", "REPLICATION", "RESET", "RESUME",
+	"RETURNED_SQLSTATE", "RETURNS", "REVERSE", "ROLLBACK", "ROLLUP",
+	"ROTATE", "ROUTINE", "ROW_COUNT", "ROW_FORMAT", "RTREE", "SAVEPOINT",
+	"SCHEDULE", "SCHEMA_NAME", "SECOND", "SECURITY", "SERIAL",
+	"SERIALIZABLE", "SERVER", "SESSION", "SHARE", "SHUTDOWN", "SIGNED",
+	"SIMPLE", "SLAVE", "SLOW", "SNAPSHOT", "SOCKET", "SOME", "SONAME",
+	"SOUNDS", "SOURCE", "SQL_AFTER_GTIDS", "SQL_AFTER_MTS_GAPS",
+	"SQL_BEFORE_GTIDS", "SQL_BUFFER_RESULT", "SQL_NO_CACHE", "SQL_THREAD",
+	"SQL_TSI_DAY", "SQL_TSI_HOUR", "SQL_TSI_MINUTE", "SQL_TSI_MONTH",
+	"SQL_TSI_QUARTER", "SQL_TSI_SECOND", "SQL_TSI_WEEK", "SQL_TSI_YEAR",
+	"STACKED", "START", "STARTS", "STATS_AUTO_RECALC", "STATS_PERSISTENT",
+	"STATS_SAMPLE_PAGES", "STATUS", "STOP", "STORAGE", "STRING",
+	"SUBCLASS_ORIGIN", "SUBJECT", "SUBPARTITION", "SUBPARTITIONS",
+	"SUPER", "SUSPEND", "SWAPS", "SWITCHES", "TABLES", "TABLESPACE",
+	"TABLE_CHECKSUM", "TABLE_NAME", "TEMPORARY", "TEMPTABLE", "TEXT",
+	"THAN", "TIME", "TIMESTAMP", "TIMESTAMPADD", "TIMESTAMPDIFF",
+	"TRANSACTION", "TRIGGERS", "TRUNCATE", "TYPE", "TYPES", "UNCOMMITTED",
+	"UNDEFINED", "UNDOFILE", "UNDO_BUFFER_SIZE", "UNICODE", "UNINSTALL",
+	"UNKNOWN", "UNTIL", "UPGRADE", "USER", "USER_RESOURCES", "USE_FRM",
+	"VALIDATION", "VALUE", "VARIABLES", "VIEW", "WAIT", "WARNINGS",
+	"WEEK", "WEIGHT_STRING", "WITHOUT", "WORK", "WRAPPER", "X509", "XA",
+	"XID", "XML", "YEAR",
+}
+
+// Non-reserved in 5.7 only.
+var mysql57Keywords = []string{
+	"ANALYSE", "DES_KEY_FILE", "FUNCTION", "MASTER_SERVER_ID",
+	"PARSE_GCOL_EXPR", "REDOFILE", "ROW", "ROWS", "SQL_CACHE",
+}
+
+// Non-reserved since 8.0.
+var mysql80Keywords = []string{
+	"ACTIVE", "ADMIN", "ATTRIBUTE", "AUTHENTICATION", "BUCKETS", "BULK",
+	"CHALLENGE_RESPONSE", "CLONE", "COMPONENT", "DEFINITION",
+	"DESCRIPTION", "ENFORCED", "ENGINE_ATTRIBUTE", "EXCLUDE", "FACTOR",
+	"FAILED_LOGIN_ATTEMPTS", "FINISH", "FOLLOWING", "GEOMCOLLECTION",
+	"GET_SOURCE_PUBLIC_KEY", "GTID_ONLY", "HISTOGRAM", "HISTORY",
+	"INACTIVE", "INITIAL", "INITIATE", "INVISIBLE", "JSON_VALUE",
+	"KEYRING", "LOCKED", "NESTED", "NETWORK_NAMESPACE", "NOWAIT", "NULLS",
+	"OFF", "OJ", "OLD", "ORDINALITY", "ORGANIZATION", "OTHERS",
+	"PASSWORD_LOCK_TIME", "PATH", "PERSIST", "PERSIST_ONLY", "PRECEDING",
+	"PRIVILEGE_CHECKS_USER", "RANDOM", "REFERENCE", "REGISTRATION",
+	"REPLICA", "REPLICAS", "REQUIRE_ROW_FORMAT",
+	"REQUIRE_TABLE_PRIMARY_KEY_CHECK", "RESOURCE", "RESPECT", "RESTART",
+	"RETAIN", "RETURNING", "REUSE", "ROLE", "SECONDARY",
+	"SECONDARY_ENGINE", "SECONDARY_ENGINE_ATTRIBUTE", "SECONDARY_LOAD",
+	"SECONDARY_UNLOAD", "SKIP", "SOURCE_AUTO_POSITION", "SOURCE_BIND",
+	"SOURCE_COMPRESSION_ALGORITHMS", "SOURCE_CONNECT_RETRY",
+	"SOURCE_DELAY", "SOURCE_HEARTBEAT_PERIOD", "SOURCE_HOST",
+	"SOURCE_LOG_FILE", "SOURCE_LOG_POS", "SOURCE_PASSWORD", "SOURCE_PORT",
+	"SOURCE_PUBLIC_KEY_PATH", "SOURCE_RETRY_COUNT", "SOURCE_SSL",
+	"SOURCE_SSL_CA", "SOURCE_SSL_CAPATH", "SOURCE_SSL_CERT",
+	"SOURCE_SSL_CIPHER", "SOURCE_SSL_CRL", "SOURCE_SSL_CRLPATH",
+	"SOURCE_SSL_KEY", "SOURCE_SSL_VERIFY_SERVER_CERT",
+	"SOURCE_TLS_CIPHERSUITES", "SOURCE_TLS_VERSION", "SOURCE_USER",
+	"SOURCE_ZSTD_COMPRESSION_LEVEL", "SRID", "STREAM", "THREAD_PRIORITY",
+	"TIES", "TLS", "UNBOUNDED", "UNREGISTER", "URL", "VCPU", "VISIBLE",
+	"ZONE",
+}
+
+// Non-reserved since 8.4.
+var mysql84Keywords = []string{
+	"AUTO", "BERNOULLI", "GTIDS", "LOG", "PARSE_TREE", "S3",
+}
+
+// Non-reserved until 8.4.
+var mysqlMasterKeywords = []string{
+	"MASTER_AUTO_POSITION", "MASTER_CONNECT_RETRY", "MASTER_DELAY",
+	"MASTER_HEARTBEAT_PERIOD", "MASTER_HOST", "MASTER_LOG_FILE",
+	"MASTER_LOG_POS", "MASTER_PASSWORD", "MASTER_PORT",
+	"MASTER_RETRY_COUNT", "MASTER_SSL", "MASTER_SSL_CA",
+	"MASTER_SSL_CAPATH", "MASTER_SSL_CERT", "MASTER_SSL_CIPHER",
+	"MASTER_SSL_CRL", "MASTER_SSL_CRLPATH", "MASTER_SSL_KEY",
+	"MASTER_TLS_VERSION", "MASTER_USER",
+}
+
+// Non-reserved in 8.0 only.
+var mysql80MasterKeywords = []string{
+	"GET_MASTER_PUBLIC_KEY", "MASTER_COMPRESSION_ALGORITHMS",
+	"MASTER_PUBLIC_KEY_PATH", "MASTER_TLS_CIPHERSUITES",
+	"MASTER_ZSTD_COMPRESSION_LEVEL",
+}
@@ -0,0 +1,136 @@
+package lexer
+
+// Source: https://github.com/pingcap/tidb/blob/master/pkg/parser/keywords.go
+
+var tidbReserved = []string{
+	"ADD", "ALL", "ALTER", "ANALYZE", "AND", "ARRAY", "AS", "ASC",
+	"BETWEEN", "BIGINT", "BINARY", "BLOB", "BOTH", "BY", "CALL",
+	"CASCADE", "CASE", "CHANGE", "CHAR", "CHARACTER", "CHECK", "COLLATE",
+	"COLUMN", "CONSTRAINT", "CONTINUE", "CONVERT", "CREATE", "CROSS",
+	"CUME_DIST", "CURRENT_DATE", "CURRENT_ROLE", "CURRENT_TIME",
+	"CURRENT_TIMESTAMP", "CURRENT_USER", "CURSOR", "DATABASE",
+	"DATABASES", "DAY_HOUR", "DAY_MICROSECOND", "DAY_MINUTE",
+	"DAY_SECOND", "DECIMAL", "DEFAULT", "DELAYED", "DELETE", "DENSE_RANK",
+	"DESC", "DESCRIBE", "DISTINCT", "DISTINCTROW", "DIV", "DOUBLE",
+	"DROP", "DUAL", "ELSE", "ELSEIF", "ENCLOSED", "ESCAPED", "EXCEPT",
+	"EXISTS", "EXIT", "EXPLAIN", "FALSE", "FETCH", "FIRST_VALUE", "FLOAT",
+	"FLOAT4", "FLOAT8", "FOR", "FORCE", "FOREIGN", "FROM", "FULLTEXT",
+	"GENERATED", "GRANT", "GROUP", "GROUPS", "HAVING", "HIGH_PRIORITY",
+	"HOUR_MICROSECOND", "HOUR_MINUTE", "HOUR_SECOND", "IF", "IGNORE",
+	"ILIKE", "IN", "INDEX", "INFILE", "INNER", "INOUT", "INSERT", "INT",
+	"INT1", "INT2", "INT3", "INT4", "INT8", "INTEGER", "INTERSECT",
+	"INTERVAL", "INTO", "IS", "ITERATE", "JOIN", "KEY", "KEYS", "KILL",
+	"LAG", "LAST_VALUE", "LEAD", "LEADING", "LEAVE", "LEFT", "LIKE",
+	"LIMIT", "LINEAR", "LINES", "LOAD", "LOCALTIME", "LOCALTIMESTAMP",
+	"LOCK", "LONG", "LONGBLOB", "LONGTEXT", "LOW_PRIORITY", "MATCH",
+	"MAXVALUE", "MEDIUMBLOB", "MEDIUMINT", "MEDIUMTEXT", "MIDDLEINT",
+	"MINUTE_MICROSECOND", "MINUTE_SECOND", "MOD", "NATURAL", "NOT",
+	"NO_WRITE_TO_BINLOG", "NTH_VALUE", "NTILE", "NULL", "NUMERIC", "OF",
+	"ON", "OPTIMIZE", "OPTION", "OPTIONALLY", "OR", "ORDER", "OUT",
+	"OUTER", "OUTFILE", "OVER", "PARTITION", "PERCENT_RANK", "PRECISION",
+	"PRIMARY", "PROCEDURE", "RANGE", "RANK", "READ", "REAL", "RECURSIVE",
+	"REFERENCES", "REGEXP", "RELEASE", "RENAME", "REPEAT", "REPLACE",
+	"REQUIRE", "RESTRICT", "REVOKE", "RIGHT", "RLIKE", "ROW", "ROWS",
+	"ROW_NUMBER", "SECOND_MICROSECOND", "SELECT", "SET", "SHOW",
+	"SMALLINT", "SPATIAL", "SQL", "SQLEXCEPTION", "SQLSTATE",
+	"SQLWARNING", "SQL_BIG_RESULT", "SQL_CALC_FOUND_ROWS",
+	"SQL_SMALL_RESULT", "SSL", "STARTING", "STORED", "STRAIGHT_JOIN",
+	"TABLE", "TABLESAMPLE", "TERMINATED", "THEN", "TIDB_CURRENT_TSO",
+	"TINYBLOB", "TINYINT", "TINYTEXT", "TO", "TRAILING", "TRIGGER",
+	"TRUE", "UNION", "UNIQUE", "UNLOCK", "UNSIGNED", "UNTIL", "UPDATE",
+	"USAGE", "USE", "USING", "UTC_DATE", "UTC_TIME", "UTC_TIMESTAMP",
+	"VALUES", "VARBINARY", "VARCHAR", "VARCHARACTER", "VARYING",
+	"VIRTUAL", "WHEN", "WHERE", "WHILE", "WINDOW", "WITH", "WRITE", "XOR",
+	"YEAR_MONTH", "ZEROFILL",
+}
+
+var tidbKeywords = []string{
+	"ACCOUNT", "ACTION", "ADD_COLUMNAR_REPLICA_ON_DEMAND", "ADVISE",
+	"AFTER", "AGAINST", "AGO", "ALGORITHM", "ALWAYS", "ANY", "APPLY",
+	"ASCII", "ATTRIBUTE", "ATTRIBUTES", "AUTO_ID_CACHE", "AUTO_INCREMENT",
+	"AUTO_RANDOM", "AUTO_RANDOM_BASE", "AVG", "AVG_ROW_LENGTH", "BACKEND",
+	"BACKUP", "BACKUPS", "BDR", "BEGIN", "BERNOULLI", "BINDING",
+	"BINDINGS", "BINDING_CACHE", "BINLOG", "BIT", "BLOCK", "BOOL",
+	"BOOLEAN", "BTREE", "BYTE", "CACHE", "CALIBRATE", "CAPTURE",
+	"CASCADED", "CAUSAL", "CHAIN", "CHARSET", "CHECKPOINT", "CHECKSUM",
+	"CHECKSUM_CONCURRENCY", "CIPHER", "CLEANUP", "CLIENT",
+	"CLIENT_ERRORS_SUMMARY", "CLOSE", "CLUSTER", "CLUSTERED", "COALESCE",
+	"COLLATION", "COLUMNAR", "COLUMNS", "COLUMN_FORMAT", "COMMENT",
+	"COMMIT", "COMMITTED", "COMPACT", "COMPRESSED", "COMPRESSION",
+	"COMPRESSION_LEVEL", "COMPRESSION_TYPE", "CONCURRENCY", "CONFIG",
+	"CONNECTION", "CONSISTENCY", "CONSISTENT", "CONTEXT", "CPU",
+	"CSV_BACKSLASH_ESCAPE", "CSV_DELIMITER", "CSV_HEADER", "CSV_NOT_NULL",
+	"CSV_NULL", "CSV_SEPARATOR", "CSV_TRIM_LAST_SEPARATORS", "CURRENT",
+	"CYCLE", "DATA", "DATE", "DATETIME", "DAY", "DEALLOCATE", "DECLARE",
+	"DEFINER", "DELAY_KEY_WRITE", "DIGEST", "DIRECTORY", "DISABLE",
+	"DISABLED", "DISCARD", "DISK", "DO", "DUPLICATE", "DYNAMIC", "ENABLE",
+	"ENABLED", "ENCRYPTION", "ENCRYPTION_KEYFILE", "ENCRYPTION_METHOD",
+	"END", "ENFORCED", "ENGINE", "ENGINES", "ENGINE_ATTRIBUTE", "ENUM",
+	"ERROR", "ERRORS", "ESCAPE", "EVENT", "EVENTS", "EVOLVE", "EXCHANGE",
+	"EXCLUSIVE", "EXECUTE", "EXPANSION", "EXPIRE", "EXPLORE", "EXTENDED",
+	"FAILED_LOGIN_ATTEMPTS", "FAULTS", "FIELDS", "FILE", "FIRST", "FIXED",
+	"FLUSH", "FOLLOWING", "FORMAT", "FOUND", "FULL", "FUNCTION",
+	"GENERAL", "GLOBAL", "GRANTS", "HANDLER", "HASH", "HELP", "HISTOGRAM",
+	"HISTORY", "HOSTS", "HOUR", "HYPO", "IDENTIFIED", "IGNORE_STATS",
+	"IMPORT", "IMPORTS", "INCREMENT", "INCREMENTAL", "INDEXES",
+	"INSERT_METHOD", "INSTANCE", "INVISIBLE", "INVOKER", "IO", "IPC",
+	"ISOLATION", "ISSUER", "JSON", "KEY_BLOCK_SIZE", "LABELS", "LANGUAGE",
+	"LAST", "LASTVAL", "LAST_BACKUP", "LESS", "LEVEL", "LIST",
+	"LOAD_STATS", "LOCAL", "LOCATION", "LOCKED", "LOGS", "MASTER",
+	"MAX_CONNECTIONS_PER_HOUR", "MAX_IDXNUM", "MAX_MINUTES",
+	"MAX_QUERIES_PER_HOUR", "MAX_ROWS", "MAX_UPDATES_PER_HOUR",
+	"MAX_USER_CONNECTIONS", "MB", "MEMBER", "MEMORY", "MERGE",
+	"MICROSECOND", "MINUTE", "MINVALUE", "MIN_ROWS", "MODE", "MODIFY",
+	"MONTH", "NAMES", "NATIONAL", "NCHAR", "NEVER", "NEXT", "NEXTVAL",
+	"NO", "NOCACHE", "NOCYCLE", "NODEGROUP", "NOMAXVALUE", "NOMINVALUE",
+	"NONCLUSTERED", "NONE", "NOWAIT", "NULLS", "NVARCHAR", "OFF",
+	"OFFSET", "OLTP_READ_ONLY", "OLTP_READ_WRITE", "OLTP_WRITE_ONLY",
+	"ONLINE", "ONLY", "ON_DUPLICATE", "OPEN", "OPTIONAL", "PACK_KEYS",
+	"PAGE", "PARSER", "PARTIAL", "PARTITIONING", "PARTITIONS", "PASSWORD",
+	"PASSWORD_LOCK_TIME", "PAUSE", "PERCENT", "PER_DB", "PER_TABLE",
+	"PLUGINS", "POINT", "POLICY", "PRECEDING", "PREPARE", "PRESERVE",
+	"PRE_SPLIT_REGIONS", "PRIVILEGES", "PROCESS", "PROCESSLIST",
+	"PROFILE", "PROFILES", "PROXY", "PURGE", "QUARTER", "QUERIES",
+	"QUERY", "QUICK", "RATE_LIMIT", "REBUILD", "RECOMMEND", "RECOVER",
+	"REDUNDANT", "REFRESH", "RELOAD", "REMOVE", "REORGANIZE", "REPAIR",
+	"REPEATABLE", "REPLICA", "REPLICAS", "REPLICATION", "REQUIRED",
+	"RESOURCE", "RESPECT", "RESTART", "RESTORE", "RESTORES", "RESUME",
+	"REUSE", "REVERSE", "ROLE", "ROLLBACK", "ROLLUP", "ROUTINE",
+	"ROW_COUNT", "ROW_FORMAT", "RTREE", "RULE", "SAN", "SAVEPOINT",
+	"SECOND", "SECONDARY", "SECONDARY_ENGINE",
+	"SECONDARY_ENGINE_ATTRIBUTE", "SECONDARY_LOAD", "SECONDARY_UNLOAD",
+	"SECURITY", "SEND_CREDENTIALS_TO_TIKV", "SEPARATOR", "SEQUENCE",
+	"SERIAL", "SERIALIZABLE", "SESSION", "SETVAL", "SHARD_ROW_ID_BITS",
+	"SHARE", "SHARED", "SHUTDOWN", "SIGNED", "SIMPLE", "SKIP",
+	"SKIP_SCHEMA_FILES", "SLAVE", "SLOW", "SNAPSHOT", "SOME", "SOURCE",
+	"SQL_BUFFER_RESULT", "SQL_CACHE", "SQL_NO_CACHE", "SQL_TSI_DAY",
+	"SQL_TSI_HOUR", "SQL_TSI_MINUTE", "SQL_TSI_MONTH", "SQL_TSI_QUARTER",
+	"SQL_TSI_SECOND", "SQL_TSI_WEEK", "SQL_TSI_YEAR", "START",
+	"STATS_AUTO_RECALC", "STATS_COL_CHOICE", "STATS_COL_LIST",
+	"STATS_OPTIONS", "STATS_PERSISTENT", "STATS_SAMPLE_PAGES",
+	"STATS_SAMPLE_RATE", "STATUS", "STORAGE", "STRICT_FORMAT", "SUBJECT",
+	"SUBPARTITION", "SUBPARTITIONS", "SUPER", "SWAPS", "SWITCHES",
+	"SYSTEM", "SYSTEM_TIME", "TABLES", "TABLESPACE", "TABLE_CHECKSUM",
+	"TEMPORARY", "TEMPTABLE", "TEXT", "THAN", "TIKV_IMPORTER", "TIME",
+	"TIMEOUT", "TIMESTAMP", "TOKEN_ISSUER", "TPCC", "TPCH_10", "TRACE",
+	"TRADITIONAL", "TRANSACTION", "TRIGGERS", "TRUNCATE", "TSO", "TTL",
+	"TTL_ENABLE", "TTL_JOB_INTERVAL", "TYPE", "UNBOUNDED", "UNCOMMITTED",
+	"UNDEFINED", "UNICODE", "UNKNOWN", "UNSET", "USER", "VALIDATION",
+	"VALUE", "VARIABLES", "VECTOR", "VIEW", "VISIBLE", "WAIT",
+	"WAIT_TIFLASH_READY", "WARNINGS", "WEEK", "WEIGHT_STRING", "WITHOUT",
+	"WITH_SYS_TABLE", "WORKLOAD", "X509", "YEAR",
+}
+
+// Keywords TiDB added for its own statements, such as SPLIT TABLE or
+// SHOW STATS_META.
+var tidbOnlyKeywords = []string{
+	"ADMIN", "BATCH", "BUCKETS", "BUILTINS", "CANCEL", "CARDINALITY",
+	"CMSKETCH", "COLUMN_STATS_USAGE", "CORRELATION", "DDL", "DEPENDENCY",
+	"DEPTH", "DISTRIBUTE", "DISTRIBUTION", "DISTRIBUTIONS", "DRY",
+	"HISTOGRAMS_IN_FLIGHT", "JOB", "JOBS", "NODE_ID", "NODE_STATE",
+	"OPTIMISTIC", "PESSIMISTIC", "REGION", "REGIONS", "RESET", "RUN",
+	"SAMPLERATE", "SAMPLES", "SESSION_STATES", "SPLIT", "STATISTICS",
+	"STATS", "STATS_BUCKETS", "STATS_EXTENDED", "STATS_HEALTHY",
+	"STATS_HISTOGRAMS", "STATS_LOCKED", "STATS_META", "STATS_TOPN",
+	"TIDB", "TIFLASH", "TOPN", "WIDTH",
+}
@@ -1,41 +1,157 @@
 package lexer
 
 import (
+	"unicode/utf8"
+
 	"github.com/bagaswh/mysql-toolkit/pkg/bytes"
 )
 
 type state byte
 
+var keywordAS = []byte("AS")
+
+// wordContext tells how the last token bears on classifying the next word.
+type wordContext uint8
+
+const (
+	contextNone wordContext = iota
+	// a word here is likely an operand, like after `,` or WHERE
+	contextName
+	// after AS, an alias, or a type in `CAST(x AS DATE)`
+	contextAlias
+)
+
 type Pos struct {
 	start, end int
 }
 
+// Start returns the byte offset of the first byte of the token.
+func (p Pos) Start() int {
+	return p.start
+}
+
+// End returns the byte offset just past the last byte of the token.
+func (p Pos) End() int {
+	return p.end
+}
+
 type Lexer struct {
 	sql []byte
 
-	// reusable buffer
-	arena []byte
+	opts Options
 
 	start, curr int
+
+	// set while lexing the content of an expanded executable comment,
+	// see ExpandExecutableComment
+	suspended []byte
+	resumeAt  int
+
+	// client-side statement delimiter, see SetDelimiter
+	delimiter []byte
+	stmtStart bool
+
+	// first error met since Parse, see Err
+	err Error
+
+	// the last token other than a comment, which bears on classifying
+	// the next word, see keyword
+	prev Token
+
+	// offset of an `@` separating the user and host of an account name,
+	// see variable
+	accountAt int
+
+	// tokens scanned by Peek and not returned yet, in a ring
+	peeked            [MaxPeek]Token
+	peekHead, peekLen int
+
+	// offsets of line starts, built by the first Position call
+	lines []int
 }
 
-func NewLexer() *Lexer {
-	return &Lexer{}
+func NewLexer(opts ...Options) *Lexer {
+	l := &Lexer{accountAt: -1}
+	if len(opts) > 0 {
+		l.opts = opts[0]
+	}
+	return l
+}
+
+func (l *Lexer) Options() Options {
+	return l.opts
 }
 
 func (l *Lexer) Parse(sql []byte) {
 	l.sql = sql
-	l.initArena()
+	l.suspended = nil
+	l.err = Error{}
+	l.peekLen = 0
+	l.lines = l.lines[:0]
 }
 
-func (l *Lexer) initArena() {
-	if l.arena == nil || cap(l.arena) < len(l.sql) {
-		l.arena = make([]byte, 0, max(len(l.sql), 1024))
+func (l *Lexer) Reset() {
+	if l.suspended != nil {
+		l.sql = l.suspended
+		l.suspended = nil
 	}
+	l.curr = 0
+	l.stmtStart = true
+	l.err = Error{}
+	l.prev = Token{}
+	l.accountAt = -1
+	l.peekLen = 0
 }
 
-func (l *Lexer) Reset() {
-	l.curr = 0
+// Err returns the first error met since the last Parse or Reset, or nil.
+// Lexing does not stop on errors: the offending token spans to the end
+// of input and has TokenAttrUnterminated set.
+func (l *Lexer) Err() error {
+	if l.err.Kind == 0 {
+		return nil
+	}
+	err := l.err
+	return &err
+}
+
+func (l *Lexer) unterminated(typ TokenType, kind ErrorKind) Token {
+	if l.err.Kind == 0 {
+		l.err = Error{Kind: kind, Offset: l.start}
+	}
+	return Token{
+		Type: typ,
+		Pos:  Pos{l.start, l.curr},
+		Attr: TokenAttrUnterminated,
+	}
+}
+
+// ExpandExecutableComment makes the lexer scan the content of tok, a
+// TokenExecutableComment just returned by NextToken, as regular SQL.
+// Lexing continues after the comment once its content is exhausted.
+func (l *Lexer) ExpandExecutableComment(tok Token) {
+	if tok.Type != TokenExecutableComment || l.suspended != nil {
+		return
+	}
+	start := tok.Pos.start + len("/*!") + versionDigits(l.sql[tok.Pos.start+len("/*!"):tok.Pos.end])
+	end := tok.Pos.end
+	if tok.Attr&TokenAttrUnterminated == 0 {
+		end -= len("*/")
+	}
+	if start > end {
+		start = end
+	}
+	l.suspended = l.sql
+	l.resumeAt = tok.Pos.end
+	l.sql = l.sql[:end]
+	l.curr = start
+	// peeked tokens come from after the comment
+	l.peekLen = 0
+}
+
+func (l *Lexer) resume() {
+	l.sql = l.suspended
+	l.suspended = nil
+	l.curr = l.resumeAt
 }
 
 func (l *Lexer) GetLexeme(token Token) []byte {
@@ -53,22 +169,60 @@ func (l *Lexer) GetLexemeNoAlloc(token Token, result []byte) (int, []byte) {
 }
 
 func (l *Lexer) NextToken() Token {
-	for l.curr < len(l.sql) {
-		l.start = l.curr
-		tok := l.scanToken()
-		if tok != (Token{}) {
-			return tok
+	if l.peekLen > 0 {
+		tok := l.peeked[l.peekHead]
+		l.peekHead = (l.peekHead + 1) % MaxPeek
+		l.peekLen--
+		return tok
+	}
+	return l.scan()
+}
+
+func (l *Lexer) scan() Token {
+	for {
+		for l.curr < len(l.sql) {
+			if isWhiteSpace(l.sql[l.curr]) {
+				if l.opts.Trivia {
+					return l.whitespace()
+				}
+				l.curr++
+				continue
+			}
+			l.start = l.curr
+			if l.delimiter != nil {
+				if tok := l.clientDelimiter(); tok != (Token{}) {
+					return tok
+				}
+			}
+			tok := l.scanToken()
+			if tok != (Token{}) {
+				if !tok.IsComment() {
+					if l.delimiter != nil {
+						l.stmtStart = false
+					}
+					l.prev = tok
+					if l.curr < len(l.sql) && l.sql[l.curr] == '@' && l.accountUser(tok) {
+						l.accountAt = l.curr
+					}
+				}
+				return tok
+			}
+			if l.opts.Trivia && l.curr > l.start {
+				return Token{Type: TokenUnknown, Pos: Pos{l.start, l.curr}}
+			}
 		}
+		if l.suspended == nil {
+			return EOF
+		}
+		l.resume()
 	}
-	return EOF
 }
 
 func (l *Lexer) scanToken() Token {
-	ch := l.advance()
-	if ch == nil {
+	c, ok := l.advance()
+	if !ok {
 		return EOF
 	}
-	c := ch[0]
 	switch c {
 	case backslash:
 		return l.backslash()
@@ -84,12 +238,25 @@ func (l *Lexer) scanToken() Token {
 	case ')':
 		pos := Pos{l.curr - 1, l.curr}
 		return Token{Type: TokenCloseParen, Pos: pos}
+	case '?':
+		pos := Pos{l.curr - 1, l.curr}
+		return Token{Type: TokenPlaceholder, Pos: pos, Attr: TokenAttrPlaceholderPositional}
+	case ';':
+		pos := Pos{l.curr - 1, l.curr}
+		return Token{Type: TokenSemicolon, Pos: pos}
 	case 0:
+		if l.opts.Trivia {
+			return Token{}
+		}
 		return EOF
 	default:
 		if isWhiteSpace(c) {
 			return Token{}
 		}
+		// most words are plain identifiers and keywords
+		if charClass[c]&(classAlpha|classLiteralPrefix) == classAlpha {
+			return l.keyword(c)
+		}
 
 		// try comment
 		tok := l.comment(c)
@@ -108,6 +275,16 @@ func (l *Lexer) scanToken() Token {
 			return Token{Type: TokenDot, Pos: pos}
 		}
 
+		tok = l.placeholder(c)
+		if tok != (Token{}) {
+			return tok
+		}
+
+		tok = l.variable(c)
+		if tok != (Token{}) {
+			return tok
+		}
+
 		tok = l.keyword(c)
 		if tok != (Token{}) {
 			return tok
@@ -149,28 +326,60 @@ func (l *Lexer) comment(c byte) Token {
 		return l.lineComment()
 	}
 	next := l.peek()
-	if c == '-' && next == '-' {
+	if c == '-' && next == '-' && l.dashCommentAt(l.curr+1) {
 		return l.lineComment()
 	}
 	if c == '/' && next == '*' {
-		l.stepForwardN(2)
-		return l.blockComment()
+		l.stepForward()
+		switch l.peek() {
+		case '!':
+			// `/*!80000 ... */` is executed by servers at or above the version
+			l.stepForward()
+			n := versionDigits(l.sql[l.curr:])
+			version := 0
+			for _, d := range l.sql[l.curr : l.curr+n] {
+				version = version*10 + int(d-'0')
+			}
+			l.stepForwardN(n)
+			tok := l.blockComment(TokenExecutableComment)
+			tok.Attr |= Attr(version) << attrVersionShift
+			return tok
+		case '+':
+			return l.blockComment(TokenOptimizerHint)
+		}
+		return l.blockComment(TokenComment)
 	}
 	return Token{}
 }
 
-func (l *Lexer) blockComment() Token {
+// dashCommentAt reports whether `--` before offset i starts a comment:
+// like the server, it must be followed by whitespace, a control character
+// or the end of input, so `a--1` is `a - -1`.
+func (l *Lexer) dashCommentAt(i int) bool {
+	return i >= len(l.sql) || l.sql[i] <= ' ' || l.sql[i] == 0x7f
+}
+
+// versionDigits returns the length of the version number at the start of
+// an executable comment's body: five digits, or six if a sixth follows.
+func versionDigits(b []byte) int {
+	n := 0
+	for n < len(b) && n < 6 && isDigit(b[n]) {
+		n++
+	}
+	if n < 5 {
+		return 0
+	}
+	return n
+}
+
+func (l *Lexer) blockComment(typ TokenType) Token {
 	depth := 1 // to handle mested block comment
 	for {
-		ch := l.advance()
-		next := l.peek()
-		if ch == nil {
-			return Token{
-				Type: TokenComment,
-				Pos:  Pos{l.start, l.curr},
-			}
+		c, ok := l.advance()
+		if !ok {
+			return l.unterminated(typ, ErrorUnterminatedComment)
 		}
-		c := ch[0]
+		next := l.peek()
 		if c == '/' && next == '*' {
 			l.stepForwardN(1)
 			depth++
@@ -183,7 +392,7 @@ func (l *Lexer) blockComment() Token {
 			// fmt.Println("nested comment exit", depth, string(l.sql[l.start:l.curr]))
 			if depth <= 0 {
 				return Token{
-					Type: TokenComment,
+					Type: typ,
 					Pos:  Pos{l.start, l.curr},
 				}
 			}
@@ -193,132 +402,238 @@ func (l *Lexer) blockComment() Token {
 
 func (l *Lexer) lineComment() Token {
 	for {
-		ch := l.advance()
-		if ch == nil {
+		c, ok := l.advance()
+		if !ok {
 			return Token{
 				Type: TokenComment,
 				Pos:  Pos{l.start, l.curr},
 			}
 		}
-		c := ch[0]
 		if c == '\n' {
+			// the newline is whitespace, not part of the comment
+			l.curr--
 			return Token{
 				Type: TokenComment,
-				Pos:  Pos{l.start, l.curr - 1},
+				Pos:  Pos{l.start, l.curr},
 			}
 		}
 	}
 }
 
-func (l *Lexer) operator(c byte) Token {
-	for {
-		// if l.isAtEnd() {
-		// 	goto ret
-		// }
-		next := l.peek()
-		// the string conversion here is free
-		// source: https://dave.cheney.net/high-performance-go-workshop/gophercon-2019.html#using_byte_as_a_map_key
-		_, ok1 := validOperators[string(c)]
-		_, ok2 := validOperators[string(next)]
-		if !(ok1 && ok2) {
-			goto ret
-		}
-		ch := l.advance()
-		if ch == nil {
-			break
-		}
+// whitespace scans a run of whitespace in trivia mode.
+func (l *Lexer) whitespace() Token {
+	l.start = l.curr
+	for l.curr < len(l.sql) && isWhiteSpace(l.sql[l.curr]) {
+		l.curr++
 	}
+	return Token{Type: TokenWhitespace, Pos: Pos{l.start, l.curr}}
+}
 
-ret:
+func (l *Lexer) operator(c byte) Token {
+	// the longest operator wins, so `a=-1` is `=` then `-1`
+	n := operatorsTrie.match(l.sql[l.start:])
+	if n == 0 {
+		return Token{}
+	}
+	l.curr = l.start + n
 	op := l.sql[l.start:l.curr]
-	if _, ok := validOperators[string(op)]; ok {
-		return Token{
-			Type: TokenOperator,
-			Pos:  Pos{l.start, l.curr},
-		}
+	var attr Attr
+	if len(op) == 2 && op[0] == '|' && op[1] == '|' && l.opts.has(SQLModePipesAsConcat) {
+		attr = TokenAttrConcat
+	}
+	return Token{
+		Type: TokenOperator,
+		Pos:  Pos{l.start, l.curr},
+		Attr: attr,
 	}
-	return Token{}
 }
 
 func (l *Lexer) keyword(c byte) Token {
-	if c == backtick {
-		return l.quotedIdentifier()
+	if c == backtick || (c == doubleQuote && l.opts.has(SQLModeANSIQuotes)) {
+		return l.quotedIdentifier(c)
 	}
 
-	if !isAlpha(c) {
+	if (c < utf8.RuneSelf && !isIdentifierChar(c)) || isDigit(c) {
 		return Token{}
 	}
 
-	var attr Attr
+	// keywords are ASCII, hash them while scanning
+	h := uint64(fnvOffset)
+	ascii := true
 
 	start := l.start
-	for {
-		c = l.peek()
-		// ahead := l.ahead()
-		if isAlpha(c) ||
-			isDigit(c) ||
-			c == underscore {
-			l.advance()
+	sql := l.sql
+	i := start
+	for i < len(sql) {
+		c := sql[i]
+		if c < utf8.RuneSelf {
+			if !isIdentifierChar(c) {
+				break
+			}
+			h = hashFoldByte(h, c)
+			i++
 			continue
 		}
-		// if c == dot && (isAlpha(ahead) || isDigit(ahead)) {
-		// 	// dots in keyword indicate it's a db/table/column reference
-		// 	l.advance()
-		// 	continue
-		// }
-		break
+		if l.opts.Charset != CharsetUTF8MB4 {
+			ascii = false
+			i += max(l.opts.Charset.mbLen(sql[i:]), 1)
+			continue
+		}
+		// like MySQL, any character from U+0080 to U+FFFF is accepted
+		r, n := utf8.DecodeRune(sql[i:])
+		if r == utf8.RuneError && n == 1 || r > 0xFFFF {
+			break
+		}
+		ascii = false
+		i += n
+	}
+	if l.delimiter != nil {
+		// like the client, the delimiter ends the word, as in `END$$`
+		for j := start + 1; j < i; j++ {
+			if l.delimiterAt(j) {
+				i = j
+				h, ascii = uint64(fnvOffset), true
+				for _, c := range sql[start:i] {
+					h = hashFoldByte(h, c)
+					ascii = ascii && c < utf8.RuneSelf
+				}
+				break
+			}
+		}
+	}
+	l.curr = i
+	if l.curr == start {
+		// skip the character, or the byte if it is not valid UTF-8
+		kind, n := ErrorInvalidUTF8, 1
+		if r, size := utf8.DecodeRune(sql[start:]); r != utf8.RuneError || size > 1 {
+			kind, n = ErrorInvalidCharacter, size
+		}
+		if l.err.Kind == 0 {
+			l.err = Error{Kind: kind, Offset: start}
+		}
+		l.curr = start + n
+		return Token{}
+	}
+	var attr Attr
+	if l.prev.Type == TokenDot || l.peek() == '.' {
+		// parts of a qualified name are never keywords, as in `db.order`
+		attr = TokenKeywordIdentifierWithDot
+	} else if ascii {
+		attr = l.getKeywordAttr(l.sql[start:l.curr], h)
+		if attr&(TokenAttrBuiltIn|KeywordAttrReserved|KeywordAttrBuiltinLiteral|keywordAttrFunction) == TokenAttrBuiltIn {
+			// a non-reserved keyword in place of an operand, like
+			// `status` in `WHERE status = 1`, is a column name
+			if context := l.contextAfter(l.prev); context != contextNone && l.atOperandEnd(context) {
+				attr = 0
+			}
+		}
 	}
 	return Token{
 		Type: TokenKeyword,
 		Pos:  Pos{start, l.curr},
-		Attr: l.getKeywordAttr(attr, start),
+		Attr: attr,
 	}
 }
 
-func (l *Lexer) getKeywordAttr(currentAttr Attr, start int) Attr {
-	var attr Attr
-	arena := l.arena[:l.curr-start]
-	copy(arena, l.sql[start:l.curr])
-	bytes.ToUpperInPlace(arena)
-	if keyTyp, ok := isBuiltInKeyword(arena); ok {
+// atOperandEnd reports whether what follows the current position can
+// end an operand: the end of the statement, `,`, `)`, an operator or a
+// reserved keyword.
+func (l *Lexer) atOperandEnd(context wordContext) bool {
+	sql := l.sql
+	i := l.curr
+	for i < len(sql) && isWhiteSpace(sql[i]) {
+		i++
+	}
+	if i == len(sql) || l.delimiterAt(i) {
+		return true
+	}
+	c := sql[i]
+	switch {
+	case c == ')':
+		return context != contextAlias
+	case c == ',' || c == ';' || charClass[c]&classOperator != 0:
+		return true
+	case isIdentifierChar(c) && !isDigit(c):
+		h := uint64(fnvOffset)
+		j := i
+		for j < len(sql) && isIdentifierChar(sql[j]) {
+			h = hashFoldByte(h, sql[j])
+			j++
+		}
+		kw, ok := isBuiltInKeyword(sql[i:j], h, l.opts.Dialect)
+		return ok && kw.Attr&KeywordAttrReserved != 0
+	}
+	return false
+}
+
+// contextAfter returns how a word following tok is classified. It is only
+// worked out for the non-reserved keywords, which it may turn into names.
+func (l *Lexer) contextAfter(tok Token) wordContext {
+	switch tok.Type {
+	case TokenComma, TokenOpenParen, TokenOperator, TokenStar:
+		return contextName
+	case TokenKeyword:
+		if tok.Attr&KeywordAttrReserved != 0 {
+			word := l.sql[tok.Pos.start:tok.Pos.end]
+			if k, ok := keywords.lookup(word); ok && k.beforeOperand {
+				if bytes.EqualFold(word, keywordAS) {
+					return contextAlias
+				}
+				return contextName
+			}
+		}
+	}
+	return contextNone
+}
+
+func (l *Lexer) getKeywordAttr(word []byte, h uint64) Attr {
+	keyTyp, ok := isBuiltInKeyword(word, h, l.opts.Dialect)
+	attr := keyTyp.Attr
+	// like the server without IGNORE_SPACE, a function name must be
+	// followed by `(` right away
+	if attr&KeywordAttrBuiltInFunction != 0 && l.peek() != '(' {
+		attr &^= keywordAttrFunction
+	}
+	if ok || attr&KeywordAttrBuiltInFunction != 0 {
 		attr |= TokenAttrBuiltIn
-		attr |= keyTyp.Attr
 	}
-	return currentAttr | attr
+	return attr
 }
 
-func (l *Lexer) quotedIdentifier() Token {
+func (l *Lexer) quotedIdentifier(quote byte) Token {
 	start := l.start
 	escaped := false
 
-	var attr Attr
-
 	for {
-		ch := l.advance()
-		if ch == nil {
-			return Token{
-				Type: TokenKeyword,
-				Pos:  Pos{},
-				Attr: l.getKeywordAttr(attr, start),
-			}
+		c, ok := l.advance()
+		if !ok {
+			return l.unterminated(TokenKeyword, ErrorUnterminatedIdentifier)
 		}
-		c := ch[0]
 
-		if c == backtick {
+		if l.skipMultibyte(c) {
+			escaped = false
+			continue
+		}
+
+		if c == quote {
 			if escaped {
 				escaped = false
 				continue
 			}
-			// Check for escaped backtick (double backtick)
-			if l.peek() == backtick {
+			// Check for escaped quote (doubled quote)
+			if l.peek() == quote {
 				escaped = true
 				continue
 			}
 			// End of quoted identifier
+			var attr Attr
+			if l.prev.Type == TokenDot || l.peek() == '.' {
+				attr = TokenKeywordIdentifierWithDot
+			}
 			return Token{
 				Type: TokenKeyword,
 				Pos:  Pos{start, l.curr},
-				Attr: l.getKeywordAttr(attr, start),
+				Attr: attr,
 			}
 		}
 
@@ -326,60 +641,320 @@ func (l *Lexer) quotedIdentifier() Token {
 	}
 }
 
+// placeholder scans named (`:name`) and numbered (`$1`) parameters.
+// Positional `?` is handled directly in scanToken.
+func (l *Lexer) placeholder(c byte) Token {
+	next := l.peek()
+	var attr Attr
+	if c == ':' && (isAlpha(next) || next == underscore) {
+		attr = TokenAttrPlaceholderNamed
+	} else if c == '$' && isDigit(next) {
+		attr = TokenAttrPlaceholderNumbered
+	} else {
+		return Token{}
+	}
+
+	for {
+		c = l.peek()
+		if attr == TokenAttrPlaceholderNamed && (isAlpha(c) || isDigit(c) || c == underscore) ||
+			attr == TokenAttrPlaceholderNumbered && isDigit(c) {
+			l.stepForward()
+			continue
+		}
+		break
+	}
+	return Token{
+		Type: TokenPlaceholder,
+		Pos:  Pos{l.start, l.curr},
+		Attr: attr,
+	}
+}
+
+func (l *Lexer) variable(c byte) Token {
+	if c != '@' {
+		return Token{}
+	}
+
+	// `'user'@'host'` account names: the `@` only separates the two parts.
+	// `@@` always starts a system variable.
+	if l.accountAt == l.start && l.peek() != '@' {
+		return Token{
+			Type: TokenOperator,
+			Pos:  Pos{l.start, l.curr},
+		}
+	}
+
+	if l.peek() == '@' {
+		l.stepForward()
+		return l.systemVariable()
+	}
+
+	var tok Token
+	switch quote := l.peek(); quote {
+	case singleQuote, doubleQuote:
+		l.stepForward()
+		tok = l.stringLiteral(quote)
+	case backtick:
+		l.stepForward()
+		tok = l.quotedIdentifier(quote)
+	default:
+		// unlike system variables, user variable names may contain dots
+		for c := l.peek(); (isIdentifierChar(c) || c == dot) && !l.delimiterAt(l.curr); c = l.peek() {
+			l.stepForward()
+		}
+		tok = Token{Pos: Pos{l.start, l.curr}}
+	}
+	tok.Type = TokenVariable
+	tok.Attr = TokenAttrUserVariable
+	return tok
+}
+
+// accountUser reports whether tok can be the user part of an account name
+// when `@` follows it right away: a quoted name or a word, but not a
+// reserved word as in `SET@x`.
+func (l *Lexer) accountUser(tok Token) bool {
+	switch c := l.sql[tok.Pos.end-1]; {
+	case c == singleQuote || c == doubleQuote || c == backtick:
+		return true
+	case isIdentifierChar(c) || c >= utf8.RuneSelf:
+		return tok.Type != TokenKeyword || !IsReservedKeyword(tok.LexemeRef(l.sql), l.opts.Dialect)
+	}
+	return false
+}
+
+var (
+	scopeGlobal      = []byte("GLOBAL")
+	scopeSession     = []byte("SESSION")
+	scopeLocal       = []byte("LOCAL")
+	scopePersist     = []byte("PERSIST")
+	scopePersistOnly = []byte("PERSIST_ONLY")
+)
+
+func (l *Lexer) systemVariable() Token {
+	attr := TokenAttrSystemVariable
+	nameStart := l.curr
+	l.variableName()
+
+	// `@@global.name`, `@@session.name`, ...
+	if l.peek() == dot && isIdentifierChar(l.ahead()) {
+		scope := l.sql[nameStart:l.curr]
+		switch {
+		case bytes.EqualFold(scope, scopeGlobal):
+			attr |= TokenAttrScopeGlobal
+		case bytes.EqualFold(scope, scopeSession), bytes.EqualFold(scope, scopeLocal):
+			attr |= TokenAttrScopeSession
+		case bytes.EqualFold(scope, scopePersist):
+			attr |= TokenAttrScopePersist
+		case bytes.EqualFold(scope, scopePersistOnly):
+			attr |= TokenAttrScopePersistOnly
+		}
+		// structured variables, e.g. `@@global.keycache1.key_buffer_size`
+		for l.peek() == dot && isIdentifierChar(l.ahead()) {
+			l.stepForward()
+			l.variableName()
+		}
+	}
+
+	return Token{
+		Type: TokenVariable,
+		Pos:  Pos{l.start, l.curr},
+		Attr: attr,
+	}
+}
+
+func (l *Lexer) variableName() {
+	for isIdentifierChar(l.peek()) && !l.delimiterAt(l.curr) {
+		l.stepForward()
+	}
+}
+
 func (l *Lexer) literal(c byte) Token {
+	if tok := l.prefixedLiteral(c); tok != (Token{}) {
+		return tok
+	}
 
 	curr := l.peek()
 
-	if c == singleQuote || c == doubleQuote {
+	if l.isStringQuote(c) {
 		return l.stringLiteral(c)
 	}
 	if (c == 'b' || c == 'B') && curr == singleQuote {
-		l.advance()
+		l.stepForward()
 		return l.bitValueLiterals("b'")
 	}
 	if c == '0' && (curr == 'B' || curr == 'b') {
-		l.advance()
+		l.stepForward()
 		return l.bitValueLiterals("0b")
 	}
 	if (c == 'X' || c == 'x') && curr == singleQuote {
-		l.advance()
+		l.stepForward()
 		return l.hexLiteral("x'")
 	}
 	if c == '0' && (curr == 'X' || curr == 'x') {
-		l.advance()
+		l.stepForward()
 		return l.hexLiteral("0x")
 	}
-	if ((c == dash || c == plus || c == dot) && isDigit(curr)) ||
+	if ((c == dot || (c == dash || c == plus) && !l.afterOperand()) && isDigit(curr)) ||
 		isDigit(c) {
 		return l.numberLiteral()
 	}
 	return Token{}
 }
 
+// afterOperand reports whether the last token ends an operand, so that a
+// `-` or `+` after it is a binary operator rather than the sign of a
+// number, as in `5-3` or `a+1`.
+func (l *Lexer) afterOperand() bool {
+	switch l.prev.Type {
+	case TokenLiteral, TokenCloseParen, TokenPlaceholder, TokenVariable:
+		return true
+	case TokenKeyword:
+		return l.prev.Attr&TokenAttrBuiltIn == 0
+	}
+	return false
+}
+
+var (
+	wordDate      = []byte("DATE")
+	wordTime      = []byte("TIME")
+	wordTimestamp = []byte("TIMESTAMP")
+)
+
+// prefixedLiteral scans literals that start with a word: charset
+// introducers (`_utf8mb4'abc'`, `_binary 0xFF`), national strings
+// (`N'abc'`) and typed temporal literals (`DATE '2024-01-01'`).
+// The prefix becomes part of the literal token.
+func (l *Lexer) prefixedLiteral(c byte) Token {
+	switch c {
+	case underscore, 'n', 'N', 'd', 'D', 't', 'T':
+	default:
+		return Token{}
+	}
+
+	wordEnd := l.curr
+	for wordEnd < len(l.sql) && isWordChar(l.sql[wordEnd]) {
+		wordEnd++
+	}
+	word := l.sql[l.start:wordEnd]
+
+	var attr Attr
+	switch {
+	case c == underscore:
+		if !isCharsetName(word[1:]) {
+			return Token{}
+		}
+		attr = TokenAttrLiteralIntroducer
+	case len(word) == 1 && (c == 'n' || c == 'N'):
+		if wordEnd >= len(l.sql) || l.sql[wordEnd] != singleQuote {
+			return Token{}
+		}
+		attr = TokenAttrLiteralNational
+	case bytes.EqualFold(word, wordDate):
+		attr = TokenAttrLiteralDate
+	case bytes.EqualFold(word, wordTime):
+		attr = TokenAttrLiteralTime
+	case bytes.EqualFold(word, wordTimestamp):
+		attr = TokenAttrLiteralTimestamp
+	default:
+		return Token{}
+	}
+
+	next := wordEnd
+	for next < len(l.sql) && isWhiteSpace(l.sql[next]) {
+		next++
+	}
+	if next >= len(l.sql) {
+		return Token{}
+	}
+
+	first := l.sql[next]
+	second := byte(0)
+	if next+1 < len(l.sql) {
+		second = l.sql[next+1]
+	}
+	switch {
+	case l.isStringQuote(first):
+	case attr != TokenAttrLiteralIntroducer:
+		// national and temporal literals only take strings
+		return Token{}
+	case (first == 'x' || first == 'X' || first == 'b' || first == 'B') && second == singleQuote:
+	case first == '0' && (second == 'x' || second == 'X' || second == 'b' || second == 'B'):
+	default:
+		return Token{}
+	}
+
+	l.curr = next + 1
+	if l.isStringQuote(first) {
+		tok := l.stringLiteral(first)
+		tok.Attr |= attr
+		return tok
+	}
+	tok := l.literal(first)
+	if tok != (Token{}) {
+		tok.Attr |= attr
+	}
+	return tok
+}
+
+// https://dev.mysql.com/doc/refman/8.0/en/charset-charsets.html
+var charsetNames = [][]byte{
+	[]byte("armscii8"), []byte("ascii"), []byte("big5"), []byte("binary"),
+	[]byte("cp1250"), []byte("cp1251"), []byte("cp1256"), []byte("cp1257"),
+	[]byte("cp850"), []byte("cp852"), []byte("cp866"), []byte("cp932"),
+	[]byte("dec8"), []byte("eucjpms"), []byte("euckr"), []byte("gb18030"),
+	[]byte("gb2312"), []byte("gbk"), []byte("geostd8"), []byte("greek"),
+	[]byte("hebrew"), []byte("hp8"), []byte("keybcs2"), []byte("koi8r"),
+	[]byte("koi8u"), []byte("latin1"), []byte("latin2"), []byte("latin5"),
+	[]byte("latin7"), []byte("macce"), []byte("macroman"), []byte("sjis"),
+	[]byte("swe7"), []byte("tis620"), []byte("ucs2"), []byte("ujis"),
+	[]byte("utf16"), []byte("utf16le"), []byte("utf32"), []byte("utf8"),
+	[]byte("utf8mb3"), []byte("utf8mb4"),
+}
+
+func isCharsetName(name []byte) bool {
+	for _, cs := range charsetNames {
+		if bytes.EqualFold(name, cs) {
+			return true
+		}
+	}
+	return false
+}
+
+func (l *Lexer) isStringQuote(c byte) bool {
+	return c == singleQuote || (c == doubleQuote && !l.opts.has(SQLModeANSIQuotes))
+}
+
 func (l *Lexer) stringLiteral(c byte) Token {
 	quoteType := c // either ' or "
 	escaped := false
+	backslashEscapes := !l.opts.has(SQLModeNoBackslashEscapes)
 
 	for {
-		ch := l.advance()
-		if ch == nil {
-			// Unterminated string literal
-			return Token{
-				Type: TokenLiteral,
-				Pos:  Pos{l.start, l.curr},
-			}
+		c, ok := l.advance()
+		if !ok {
+			return l.unterminatedLiteral(TokenAttrLiteralString)
+		}
+
+		if l.skipMultibyte(c) {
+			escaped = false
+			continue
 		}
-		c := ch[0]
 
-		if c == backslash {
+		if c == backslash && backslashEscapes {
 			escaped = !escaped
 			continue
 		}
 
 		if c == quoteType && !escaped {
+			// a doubled quote is an escaped quote
+			if l.peek() == quoteType {
+				l.stepForward()
+				continue
+			}
 			return Token{
 				Type: TokenLiteral,
 				Pos:  Pos{l.start, l.curr},
+				Attr: TokenAttrLiteralString,
 			}
 		}
 
@@ -388,72 +963,105 @@ func (l *Lexer) stringLiteral(c byte) Token {
 }
 
 func (l *Lexer) numberLiteral() Token {
+	attr := TokenAttrLiteralInteger
+	if l.sql[l.start] == dot {
+		attr = TokenAttrLiteralDecimal
+	}
 	for {
 		curr := l.peek()
-		currIsDigit := isDigit(curr)
-		next := l.ahead()
-		nextIsDigit := isDigit(next)
-		if (currIsDigit ||
-			(curr == dash && nextIsDigit) ||
-			(curr == dot && nextIsDigit)) ||
-			((curr == 'E' || curr == 'e') && (nextIsDigit || next == dash)) {
+		switch {
+		case isDigit(curr):
 			l.stepForward()
-			continue
-		}
-		if !currIsDigit {
+		case curr == dot && attr == TokenAttrLiteralInteger:
+			// `1.` is a decimal too
+			attr = TokenAttrLiteralDecimal
+			l.stepForward()
+		case (curr == 'E' || curr == 'e') && attr != TokenAttrLiteralFloat && l.exponentAt(l.curr+1):
+			// a sign is only part of the number right after the E, so
+			// `5-3` is `5 - 3`
+			attr = TokenAttrLiteralFloat
+			l.stepForward()
+			if c := l.peek(); c == dash || c == plus {
+				l.stepForward()
+			}
+		default:
 			return Token{
 				Type: TokenLiteral,
 				Pos:  Pos{l.start, l.curr},
+				Attr: attr,
 			}
 		}
 	}
 }
 
+// exponentAt reports whether the exponent of a number, with an optional
+// sign, starts at offset i.
+func (l *Lexer) exponentAt(i int) bool {
+	if i < len(l.sql) && (l.sql[i] == dash || l.sql[i] == plus) {
+		i++
+	}
+	return i < len(l.sql) && isDigit(l.sql[i])
+}
+
+// unterminatedLiteral is like unterminated for quoted literals of kind.
+func (l *Lexer) unterminatedLiteral(kind Attr) Token {
+	tok := l.unterminated(TokenLiteral, ErrorUnterminatedString)
+	tok.Attr |= kind
+	return tok
+}
+
 func (l *Lexer) bitValueLiterals(start string) Token {
-	var tok Token
+	if start == "0b" {
+		for c := l.peek(); c == '0' || c == '1'; c = l.peek() {
+			l.stepForward()
+		}
+		return Token{
+			Type: TokenLiteral,
+			Pos:  Pos{l.start, l.curr},
+			Attr: TokenAttrLiteralBit,
+		}
+	}
+
 	for {
-		ch := l.advance()
-		if ch == nil {
-			break
+		c, ok := l.advance()
+		if !ok {
+			return l.unterminatedLiteral(TokenAttrLiteralBit)
 		}
-		c := ch[0]
-		if start == "b'" {
-			if c == '\'' {
-				tok = Token{
-					Type: TokenLiteral,
-					Pos:  Pos{l.start, l.curr},
-				}
-				break
-			}
-		} else if start == "0b" {
-			if isWhiteSpace(c) || l.isAtEnd() {
-				tok = Token{
-					Type: TokenLiteral,
-					Pos:  Pos{l.start, l.curr},
-				}
-				break
+		if c == singleQuote {
+			return Token{
+				Type: TokenLiteral,
+				Pos:  Pos{l.start, l.curr},
+				Attr: TokenAttrLiteralBit,
 			}
 		}
-		if l.isAtEnd() {
-			break
-		}
 	}
-	return tok
 }
 
 func (l *Lexer) hexLiteral(hexStart string) Token {
-	var tok Token
+	if hexStart == "0x" {
+		for isHexDigit(l.peek()) {
+			l.stepForward()
+		}
+		return Token{
+			Type: TokenLiteral,
+			Pos:  Pos{l.start, l.curr},
+			Attr: TokenAttrLiteralHex,
+		}
+	}
+
 	for {
-		ch := l.advance()
-		if ch == nil || (ch[0] == '\'' && hexStart == "x'") || (hexStart == "0x" && isWhiteSpace(ch[0])) {
-			tok = Token{
+		c, ok := l.advance()
+		if !ok {
+			return l.unterminatedLiteral(TokenAttrLiteralHex)
+		}
+		if c == singleQuote {
+			return Token{
 				Type: TokenLiteral,
 				Pos:  Pos{l.start, l.curr},
+				Attr: TokenAttrLiteralHex,
 			}
-			break
 		}
 	}
-	return tok
 }
 
 func (l *Lexer) stepForward() {
@@ -464,24 +1072,22 @@ func (l *Lexer) stepForward() {
 }
 
 func (l *Lexer) stepForwardN(n int) {
-	if l.isAtEnd() {
-		return
-	}
-	l.curr += n
+	l.curr = min(l.curr+n, len(l.sql))
 }
 
-func (l *Lexer) advance() []byte {
-	if l.isAtEnd() {
-		return nil
+// advance returns the current byte and moves past it. ok is false at the
+// end of input.
+func (l *Lexer) advance() (c byte, ok bool) {
+	if l.curr >= len(l.sql) {
+		return 0, false
 	}
-	// TODO: Why this is not BCE'd?
-	c := l.sql[l.curr]
+	c = l.sql[l.curr]
 	l.curr++
-	return []byte{c}
+	return c, true
 }
 
 func (l *Lexer) peek() byte {
-	if l.isAtEnd() {
+	if l.curr >= len(l.sql) {
 		return 0
 	}
 	return l.sql[l.curr]
@@ -495,10 +1101,10 @@ func (l *Lexer) ahead() byte {
 }
 
 func (l *Lexer) isAtEnd() bool {
-	return l.curr >= (len(l.sql))
+	return l.curr >= len(l.sql)
 }
 
-var (
+const (
 	backtick    = byte('`')
 	singleQuote = byte('\'')
 	doubleQuote = byte('"')
@@ -509,20 +1115,66 @@ var (
 
 	backslash = byte('\\')
 	dash      = byte('-')
+)
 
-	maybeLiterals = [][]byte{
-		[]byte("n"), []byte("NU"), []byte("tr"), []byte("TR"), []byte("fa"), []byte("FA"),
-	}
+const (
+	classAlpha uint8 = 1 << iota
+	classDigit
+	classHexDigit
+	classWord       // letters, digits and `_`
+	classIdentifier // classWord and `$`
+	classSpace
+	classOperator
+	// letters that may start a literal, like `x'0F'` or `DATE '...'`
+	classLiteralPrefix
 )
 
+var charClass = func() (t [256]uint8) {
+	for c := 'a'; c <= 'z'; c++ {
+		t[c] |= classAlpha | classWord | classIdentifier
+		t[c-'a'+'A'] |= classAlpha | classWord | classIdentifier
+	}
+	for c := '0'; c <= '9'; c++ {
+		t[c] |= classDigit | classHexDigit | classWord | classIdentifier
+	}
+	for c := 'a'; c <= 'f'; c++ {
+		t[c] |= classHexDigit
+		t[c-'a'+'A'] |= classHexDigit
+	}
+	t[underscore] |= classWord | classIdentifier
+	t['$'] |= classIdentifier
+	for _, c := range "\t\n\r " {
+		t[c] |= classSpace
+	}
+	for _, c := range "<>=!&|^~%+-*/" {
+		t[c] |= classOperator
+	}
+	for _, c := range "_bBdDnNtTxX" {
+		t[c] |= classLiteralPrefix
+	}
+	return t
+}()
+
 func isAlpha(c byte) bool {
-	return ((c >= 65 && c <= 90) || (c >= 97 && c <= 122))
+	return charClass[c]&classAlpha != 0
 }
 
 func isDigit(c byte) bool {
-	return c-48 <= 9
+	return charClass[c]&classDigit != 0
+}
+
+func isHexDigit(c byte) bool {
+	return charClass[c]&classHexDigit != 0
+}
+
+func isWordChar(c byte) bool {
+	return charClass[c]&classWord != 0
+}
+
+func isIdentifierChar(c byte) bool {
+	return charClass[c]&classIdentifier != 0
 }
 
 func isWhiteSpace(c byte) bool {
-	return c == '\t' || c == '\n' || c == '\r' || c == ' '
+	return charClass[c]&classSpace != 0
 }
@@ -25,6 +25,35 @@ func addIntoTokenSlice(toks []Token, lexer *Lexer) []Token {
 	return toks
 }
 
+// lexTokens lexes what l was given and returns the tokens with their
+// lexemes.
+func lexTokens(l *Lexer) ([]Token, []string) {
+	tokens := addIntoTokenSlice(nil, l)
+	lexemes := make([]string, 0, len(tokens))
+	for _, tok := range tokens {
+		lexemes = append(lexemes, string(l.GetLexeme(tok)))
+	}
+	return tokens, lexemes
+}
+
+func tokenTypes(tokens []Token) []TokenType {
+	types := make([]TokenType, 0, len(tokens))
+	for _, tok := range tokens {
+		types = append(types, tok.Type)
+	}
+	return types
+}
+
+// assertAttrs checks that each token has the attributes expected of it.
+func assertAttrs(t *testing.T, tokens []Token, attrs []Attr) {
+	t.Helper()
+	for i, tok := range tokens {
+		if tok.Attr&attrs[i] != attrs[i] {
+			t.Errorf("Token %d: expected attr %d, got %d", i, attrs[i], tok.Attr)
+		}
+	}
+}
+
 func TestLexer_BasicSQL(t *testing.T) {
 	var tokens []Token
 	l := NewLexer()
@@ -264,9 +293,20 @@ func TestLexer_Fuzzy(t *testing.T) {
 		{
 			name:            "number literals",
 			input:           "SELECT 123)12e-4+12-31233()",
-			expectedLexemes: []string{"SELECT", "123", ")", "12e-4", "+12-31233", "(", ")"},
+			expectedLexemes: []string{"SELECT", "123", ")", "12e-4", "+", "12", "-", "31233", "(", ")"},
 			expectedTokens: []TokenType{
-				TokenKeyword, TokenLiteral, TokenCloseParen, TokenLiteral, TokenLiteral, TokenOpenParen, TokenCloseParen,
+				TokenKeyword, TokenLiteral, TokenCloseParen, TokenLiteral, TokenOperator, TokenLiteral, TokenOperator, TokenLiteral,
+				TokenOpenParen, TokenCloseParen,
+			},
+		},
+		{
+			name:            "signs",
+			input:           "SELECT a-1, x*-2, (3)+4, ?-5, @v-6, 1 - -7",
+			expectedLexemes: []string{"SELECT", "a", "-", "1", ",", "x", "*", "-2", ",", "(", "3", ")", "+", "4", ",", "?", "-", "5", ",", "@v", "-", "6", ",", "1", "-", "-7"},
+			expectedTokens: []TokenType{
+				TokenKeyword, TokenKeyword, TokenOperator, TokenLiteral, TokenComma, TokenKeyword, TokenStar, TokenLiteral, TokenComma,
+				TokenOpenParen, TokenLiteral, TokenCloseParen, TokenOperator, TokenLiteral, TokenComma, TokenPlaceholder, TokenOperator,
+				TokenLiteral, TokenComma, TokenVariable, TokenOperator, TokenLiteral, TokenComma, TokenLiteral, TokenOperator, TokenLiteral,
 			},
 		},
 		{
@@ -339,6 +379,25 @@ func TestLexer_Operators(t *testing.T) {
 			input:    "= :=",
 			expected: []TokenType{TokenOperator, TokenOperator},
 		},
+		{
+			name:  "Adjacent operators",
+			input: "a=-b x<-y c>=-d e:=@f g<=>~h",
+			expected: []TokenType{
+				TokenKeyword, TokenOperator, TokenOperator, TokenKeyword,
+				TokenKeyword, TokenOperator, TokenOperator, TokenKeyword,
+				TokenKeyword, TokenOperator, TokenOperator, TokenKeyword,
+				TokenKeyword, TokenOperator, TokenVariable,
+				TokenKeyword, TokenOperator, TokenOperator, TokenKeyword,
+			},
+		},
+		{
+			name:  "Semicolons",
+			input: "SELECT 1; SELECT a->>'$.b';",
+			expected: []TokenType{
+				TokenKeyword, TokenLiteral, TokenSemicolon,
+				TokenKeyword, TokenKeyword, TokenOperator, TokenLiteral, TokenSemicolon,
+			},
+		},
 	}
 
 	for _, tt := range tests {
@@ -382,6 +441,11 @@ func TestLexer_HexLiterals(t *testing.T) {
 			input:    "SELECT X'ABCD'",
 			expected: []string{"SELECT", "X'ABCD'"},
 		},
+		{
+			name:     "0x prefix followed by punctuation",
+			input:    "SELECT 0x1F, 0xff)",
+			expected: []string{"SELECT", "0x1F", ",", "0xff", ")"},
+		},
 	}
 
 	for _, tt := range tests {
@@ -425,6 +489,11 @@ func TestLexer_BitValueLiterals(t *testing.T) {
 			input:    "SELECT B'111000'",
 			expected: []string{"SELECT", "B'111000'"},
 		},
+		{
+			name:     "0b prefix followed by punctuation",
+			input:    "SELECT 0b101, 0b1)",
+			expected: []string{"SELECT", "0b101", ",", "0b1", ")"},
+		},
 	}
 
 	for _, tt := range tests {
@@ -837,6 +906,11 @@ func TestLexer_Comment(t *testing.T) {
 				TokenComment,
 			},
 		},
+		{
+			name:     "double dash without a space",
+			sql:      "SELECT 1 ---c, a--1, b--\t2, c --",
+			expected: []string{"SELECT", "1", "-", "-", "-", "c", ",", "a", "-", "-1", ",", "b", "--\t2, c --"},
+		},
 		{
 			name:     "block comment",
 			sql:      "SELECT * FROM users WHERE id = 1 /* comment */",
@@ -953,6 +1027,869 @@ func TestLexer_Comment(t *testing.T) {
 	}
 }
 
+func TestLexer_Placeholders(t *testing.T) {
+	tests := []struct {
+		name          string
+		input         string
+		expected      []string
+		expectedTypes []TokenType
+		expectedAttrs []Attr
+	}{
+		{
+			name:          "Positional",
+			input:         "SELECT * FROM users WHERE id = ? AND name IN (?, ?)",
+			expected:      []string{"SELECT", "*", "FROM", "users", "WHERE", "id", "=", "?", "AND", "name", "IN", "(", "?", ",", "?", ")"},
+			expectedTypes: []TokenType{TokenKeyword, TokenStar, TokenKeyword, TokenKeyword, TokenKeyword, TokenKeyword, TokenOperator, TokenPlaceholder, TokenKeyword, TokenKeyword, TokenKeyword, TokenOpenParen, TokenPlaceholder, TokenComma, TokenPlaceholder, TokenCloseParen},
+			expectedAttrs: []Attr{0, 0, 0, 0, 0, 0, 0, TokenAttrPlaceholderPositional, 0, 0, 0, 0, TokenAttrPlaceholderPositional, 0, TokenAttrPlaceholderPositional, 0},
+		},
+		{
+			name:          "Named",
+			input:         "SELECT id FROM users WHERE id = :user_id1",
+			expected:      []string{"SELECT", "id", "FROM", "users", "WHERE", "id", "=", ":user_id1"},
+			expectedTypes: []TokenType{TokenKeyword, TokenKeyword, TokenKeyword, TokenKeyword, TokenKeyword, TokenKeyword, TokenOperator, TokenPlaceholder},
+			expectedAttrs: []Attr{0, 0, 0, 0, 0, 0, 0, TokenAttrPlaceholderNamed},
+		},
+		{
+			name:          "Numbered",
+			input:         "UPDATE users SET name = $1 WHERE id = $12",
+			expected:      []string{"UPDATE", "users", "SET", "name", "=", "$1", "WHERE", "id", "=", "$12"},
+			expectedTypes: []TokenType{TokenKeyword, TokenKeyword, TokenKeyword, TokenKeyword, TokenOperator, TokenPlaceholder, TokenKeyword, TokenKeyword, TokenOperator, TokenPlaceholder},
+			expectedAttrs: []Attr{0, 0, 0, 0, 0, TokenAttrPlaceholderNumbered, 0, 0, 0, TokenAttrPlaceholderNumbered},
+		},
+		{
+			name:          "Adjacent to punctuation",
+			input:         "VALUES(?,?)",
+			expected:      []string{"VALUES", "(", "?", ",", "?", ")"},
+			expectedTypes: []TokenType{TokenKeyword, TokenOpenParen, TokenPlaceholder, TokenComma, TokenPlaceholder, TokenCloseParen},
+			expectedAttrs: []Attr{0, 0, TokenAttrPlaceholderPositional, 0, TokenAttrPlaceholderPositional, 0},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			l := NewLexer()
+			l.Parse([]byte(tt.input))
+			tokens, lexemes := lexTokens(l)
+			assert.DeepEqual(t, tt.expected, lexemes)
+			assert.DeepEqual(t, tt.expectedTypes, tokenTypes(tokens))
+			assertAttrs(t, tokens, tt.expectedAttrs)
+		})
+	}
+}
+
+func TestLexer_Variables(t *testing.T) {
+	tests := []struct {
+		name          string
+		input         string
+		expected      []string
+		expectedTypes []TokenType
+		expectedAttrs []Attr
+	}{
+		{
+			name:          "User variable assignment",
+			input:         "SET @x := 1",
+			expected:      []string{"SET", "@x", ":=", "1"},
+			expectedTypes: []TokenType{TokenKeyword, TokenVariable, TokenOperator, TokenLiteral},
+			expectedAttrs: []Attr{0, TokenAttrUserVariable, 0, 0},
+		},
+		{
+			name:          "Quoted user variables",
+			input:         "SELECT @'my var', @\"other\", @`third`, @a.b$c",
+			expected:      []string{"SELECT", "@'my var'", ",", "@\"other\"", ",", "@`third`", ",", "@a.b$c"},
+			expectedTypes: []TokenType{TokenKeyword, TokenVariable, TokenComma, TokenVariable, TokenComma, TokenVariable, TokenComma, TokenVariable},
+			expectedAttrs: []Attr{0, TokenAttrUserVariable, 0, TokenAttrUserVariable, 0, TokenAttrUserVariable, 0, TokenAttrUserVariable},
+		},
+		{
+			name:          "System variable without scope",
+			input:         "SELECT @@sql_mode",
+			expected:      []string{"SELECT", "@@sql_mode"},
+			expectedTypes: []TokenType{TokenKeyword, TokenVariable},
+			expectedAttrs: []Attr{0, TokenAttrSystemVariable},
+		},
+		{
+			name:          "System variables with scope",
+			input:         "SELECT @@global.max_connections, @@SESSION.sql_mode, @@local.autocommit, @@persist.x, @@persist_only.y",
+			expected:      []string{"SELECT", "@@global.max_connections", ",", "@@SESSION.sql_mode", ",", "@@local.autocommit", ",", "@@persist.x", ",", "@@persist_only.y"},
+			expectedTypes: []TokenType{TokenKeyword, TokenVariable, TokenComma, TokenVariable, TokenComma, TokenVariable, TokenComma, TokenVariable, TokenComma, TokenVariable},
+			expectedAttrs: []Attr{
+				0, TokenAttrSystemVariable | TokenAttrScopeGlobal,
+				0, TokenAttrSystemVariable | TokenAttrScopeSession,
+				0, TokenAttrSystemVariable | TokenAttrScopeSession,
+				0, TokenAttrSystemVariable | TokenAttrScopePersist,
+				0, TokenAttrSystemVariable | TokenAttrScopePersistOnly,
+			},
+		},
+		{
+			name:          "Structured system variable",
+			input:         "SELECT @@global.keycache1.key_buffer_size",
+			expected:      []string{"SELECT", "@@global.keycache1.key_buffer_size"},
+			expectedTypes: []TokenType{TokenKeyword, TokenVariable},
+			expectedAttrs: []Attr{0, TokenAttrSystemVariable | TokenAttrScopeGlobal},
+		},
+		{
+			name:          "Account name is not a variable",
+			input:         "CREATE USER 'app'@'localhost'",
+			expected:      []string{"CREATE", "USER", "'app'", "@", "'localhost'"},
+			expectedTypes: []TokenType{TokenKeyword, TokenKeyword, TokenLiteral, TokenOperator, TokenLiteral},
+			expectedAttrs: []Attr{0, 0, 0, 0, 0},
+		},
+		{
+			name:          "Unquoted account name",
+			input:         "GRANT ALL ON *.* TO app@localhost",
+			expected:      []string{"GRANT", "ALL", "ON", "*", ".", "*", "TO", "app", "@", "localhost"},
+			expectedTypes: []TokenType{TokenKeyword, TokenKeyword, TokenKeyword, TokenStar, TokenDot, TokenStar, TokenKeyword, TokenKeyword, TokenOperator, TokenKeyword},
+			expectedAttrs: []Attr{0, 0, 0, 0, 0, 0, 0, 0, 0, 0},
+		},
+		{
+			name:          "Variable right after a reserved word",
+			input:         "SET@x=1",
+			expected:      []string{"SET", "@x", "=", "1"},
+			expectedTypes: []TokenType{TokenKeyword, TokenVariable, TokenOperator, TokenLiteral},
+			expectedAttrs: []Attr{0, TokenAttrUserVariable, 0, 0},
+		},
+		{
+			name:          "System variable right after a word",
+			input:         "SELECT@@version",
+			expected:      []string{"SELECT", "@@version"},
+			expectedTypes: []TokenType{TokenKeyword, TokenVariable},
+			expectedAttrs: []Attr{0, TokenAttrSystemVariable},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			l := NewLexer()
+			l.Parse([]byte(tt.input))
+			tokens, lexemes := lexTokens(l)
+			assert.DeepEqual(t, tt.expected, lexemes)
+			assert.DeepEqual(t, tt.expectedTypes, tokenTypes(tokens))
+			assertAttrs(t, tokens, tt.expectedAttrs)
+		})
+	}
+}
+
+func TestLexer_ExecutableCommentsAndHints(t *testing.T) {
+	tests := []struct {
+		name            string
+		input           string
+		expected        []string
+		expectedTypes   []TokenType
+		expectedVersion int
+	}{
+		{
+			name:          "Optimizer hint",
+			input:         "SELECT /*+ INDEX(t idx) */ * FROM t",
+			expected:      []string{"SELECT", "/*+ INDEX(t idx) */", "*", "FROM", "t"},
+			expectedTypes: []TokenType{TokenKeyword, TokenOptimizerHint, TokenStar, TokenKeyword, TokenKeyword},
+		},
+		{
+			name:          "Executable comment without version",
+			input:         "SELECT /*! STRAIGHT_JOIN */ 1",
+			expected:      []string{"SELECT", "/*! STRAIGHT_JOIN */", "1"},
+			expectedTypes: []TokenType{TokenKeyword, TokenExecutableComment, TokenLiteral},
+		},
+		{
+			name:            "Five digit version",
+			input:           "/*!40101 SET NAMES utf8 */",
+			expected:        []string{"/*!40101 SET NAMES utf8 */"},
+			expectedTypes:   []TokenType{TokenExecutableComment},
+			expectedVersion: 40101,
+		},
+		{
+			name:            "Six digit version",
+			input:           "/*!080034 SELECT 1 */",
+			expected:        []string{"/*!080034 SELECT 1 */"},
+			expectedTypes:   []TokenType{TokenExecutableComment},
+			expectedVersion: 80034,
+		},
+		{
+			name:          "Too short to be a version",
+			input:         "/*!123 */",
+			expected:      []string{"/*!123 */"},
+			expectedTypes: []TokenType{TokenExecutableComment},
+		},
+		{
+			name:          "Regular comment",
+			input:         "/* ! not executable */",
+			expected:      []string{"/* ! not executable */"},
+			expectedTypes: []TokenType{TokenComment},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			l := NewLexer()
+			l.Parse([]byte(tt.input))
+			tokens, lexemes := lexTokens(l)
+			assert.DeepEqual(t, tt.expected, lexemes)
+			assert.DeepEqual(t, tt.expectedTypes, tokenTypes(tokens))
+			for _, tok := range tokens {
+				if tok.Type == TokenExecutableComment && tok.MinVersion() != tt.expectedVersion {
+					t.Errorf("expected version %d, got %d", tt.expectedVersion, tok.MinVersion())
+				}
+			}
+		})
+	}
+}
+
+func TestLexer_ExpandExecutableComment(t *testing.T) {
+	l := NewLexer()
+	l.Parse([]byte("SELECT /*!50000 SQL_NO_CACHE */ a /*!80000 , b */ FROM t"))
+
+	var lexemes []string
+	for {
+		tok := l.NextToken()
+		if tok.Type == TokenEOF {
+			break
+		}
+		if tok.Type == TokenExecutableComment {
+			l.ExpandExecutableComment(tok)
+			continue
+		}
+		lexemes = append(lexemes, string(l.GetLexeme(tok)))
+	}
+	assert.DeepEqual(t, []string{"SELECT", "SQL_NO_CACHE", "a", ",", "b", "FROM", "t"}, lexemes)
+
+	// Reset restores the full input even if expansion is in progress
+	l.Reset()
+	l.NextToken()
+	l.ExpandExecutableComment(l.NextToken())
+	l.Reset()
+	_, lexemes = lexTokens(l)
+	assert.DeepEqual(t, []string{"SELECT", "/*!50000 SQL_NO_CACHE */", "a", "/*!80000 , b */", "FROM", "t"}, lexemes)
+}
+
+func TestLexer_PrefixedLiterals(t *testing.T) {
+	tests := []struct {
+		name        string
+		input       string
+		expected    []string
+		literalAttr Attr
+		introducer  string
+	}{
+		{
+			name:        "Charset introducer",
+			input:       "SELECT _utf8mb4'abc' COLLATE utf8mb4_bin",
+			expected:    []string{"SELECT", "_utf8mb4'abc'", "COLLATE", "utf8mb4_bin"},
+			literalAttr: TokenAttrLiteralIntroducer,
+			introducer:  "utf8mb4",
+		},
+		{
+			name:        "Charset introducer with space and hex",
+			input:       "SELECT _BINARY 0xFF, _latin1 X'41'",
+			expected:    []string{"SELECT", "_BINARY 0xFF", ",", "_latin1 X'41'"},
+			literalAttr: TokenAttrLiteralIntroducer,
+		},
+		{
+			name:        "Charset introducer with bit value",
+			input:       "SELECT _binary b'0101'",
+			expected:    []string{"SELECT", "_binary b'0101'"},
+			literalAttr: TokenAttrLiteralIntroducer,
+			introducer:  "binary",
+		},
+		{
+			name:        "National string",
+			input:       "SELECT N'text', n'more'",
+			expected:    []string{"SELECT", "N'text'", ",", "n'more'"},
+			literalAttr: TokenAttrLiteralNational,
+		},
+		{
+			name:        "Date literal",
+			input:       "SELECT * FROM t WHERE d = DATE '2024-01-01'",
+			expected:    []string{"SELECT", "*", "FROM", "t", "WHERE", "d", "=", "DATE '2024-01-01'"},
+			literalAttr: TokenAttrLiteralDate,
+		},
+		{
+			name:        "Time literal",
+			input:       "SELECT time'10:00:00'",
+			expected:    []string{"SELECT", "time'10:00:00'"},
+			literalAttr: TokenAttrLiteralTime,
+		},
+		{
+			name:        "Timestamp literal",
+			input:       "SELECT TIMESTAMP  '2024-01-01 10:00:00'",
+			expected:    []string{"SELECT", "TIMESTAMP  '2024-01-01 10:00:00'"},
+			literalAttr: TokenAttrLiteralTimestamp,
+		},
+		{
+			name:     "Not literal prefixes",
+			input:    "SELECT DATE(created_at), n, timestamp FROM t",
+			expected: []string{"SELECT", "DATE", "(", "created_at", ")", ",", "n", ",", "timestamp", "FROM", "t"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			l := NewLexer()
+			input := []byte(tt.input)
+			l.Parse(input)
+			tokens, lexemes := lexTokens(l)
+			for _, tok := range tokens {
+				if tok.Type == TokenLiteral {
+					if tok.Attr&tt.literalAttr != tt.literalAttr {
+						t.Errorf("%q: expected attr %d, got %d", l.GetLexeme(tok), tt.literalAttr, tok.Attr)
+					}
+					if tt.introducer != "" && string(tok.Introducer(input)) != tt.introducer {
+						t.Errorf("%q: expected introducer %q, got %q", l.GetLexeme(tok), tt.introducer, tok.Introducer(input))
+					}
+				}
+			}
+			assert.DeepEqual(t, tt.expected, lexemes)
+		})
+	}
+}
+
+func TestLexer_LiteralKinds(t *testing.T) {
+	tests := []struct {
+		input string
+		kind  Attr
+		// the first token when it is not the whole input
+		lexeme string
+	}{
+		{"42", TokenAttrLiteralInteger, ""},
+		{"-7", TokenAttrLiteralInteger, ""},
+		{"5-3", TokenAttrLiteralInteger, "5"},
+		{"4.2", TokenAttrLiteralDecimal, ""},
+		{".5", TokenAttrLiteralDecimal, ""},
+		{"1.", TokenAttrLiteralDecimal, ""},
+		{"1.2.3", TokenAttrLiteralDecimal, "1.2"},
+		{"4.2e1", TokenAttrLiteralFloat, ""},
+		{"1E-3", TokenAttrLiteralFloat, ""},
+		{"1e+5", TokenAttrLiteralFloat, ""},
+		{"1.e5", TokenAttrLiteralFloat, ""},
+		{"1e5-3", TokenAttrLiteralFloat, "1e5"},
+		{"0x1F", TokenAttrLiteralHex, ""},
+		{"X'1F'", TokenAttrLiteralHex, ""},
+		{"0b101", TokenAttrLiteralBit, ""},
+		{"b'101'", TokenAttrLiteralBit, ""},
+		{"'str'", TokenAttrLiteralString, ""},
+		{`"str"`, TokenAttrLiteralString, ""},
+		{"'unterminated", TokenAttrLiteralString, ""},
+		{"N'str'", TokenAttrLiteralString, ""},
+		{"DATE '2024-01-01'", TokenAttrLiteralString, ""},
+		{"_binary 0xFF", TokenAttrLiteralHex, ""},
+		{"TRUE", TokenAttrLiteralBoolean, ""},
+		{"false", TokenAttrLiteralBoolean, ""},
+		{"NULL", TokenAttrLiteralNull, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			lexer := NewLexer()
+			lexer.Parse([]byte(tt.input))
+			lexer.Reset()
+			tok := lexer.NextToken()
+			assert.Equal(t, tt.kind, tok.Attr&TokenAttrLiteralKind)
+			if tt.lexeme != "" {
+				assert.Equal(t, tt.lexeme, string(lexer.GetLexeme(tok)))
+				return
+			}
+			assert.Equal(t, tt.input, string(lexer.GetLexeme(tok)))
+			assert.Equal(t, TokenEOF, lexer.NextToken().Type)
+		})
+	}
+}
+
+func TestLexer_Charset(t *testing.T) {
+	tests := []struct {
+		name     string
+		charset  Charset
+		input    string
+		expected []string
+	}{
+		{
+			name:     "utf8mb4 escapes the quote",
+			input:    "SELECT '\xbf\x5c' OR 1=1 -- '",
+			expected: []string{"SELECT", "'\xbf\x5c' OR 1=1 -- '"},
+		},
+		{
+			name:     "gbk",
+			charset:  CharsetGBK,
+			input:    "SELECT '\xbf\x5c' OR 1=1 -- '",
+			expected: []string{"SELECT", "'\xbf\x5c'", "OR", "1", "=", "1", "-- '"},
+		},
+		{
+			name:     "big5",
+			charset:  CharsetBig5,
+			input:    "SELECT '\xb3\x5c', \xb3\x5c\xa5\x40 FROM t",
+			expected: []string{"SELECT", "'\xb3\x5c'", ",", "\xb3\x5c\xa5\x40", "FROM", "t"},
+		},
+		{
+			name:     "sjis",
+			charset:  CharsetSJIS,
+			input:    "SELECT \"\x95\x5c\", `\x83\x60` FROM t",
+			expected: []string{"SELECT", "\"\x95\x5c\"", ",", "`\x83\x60`", "FROM", "t"},
+		},
+		{
+			name:     "sjis escape after a multibyte character",
+			charset:  CharsetSJIS,
+			input:    "SELECT '\x95\x5c\\'' FROM t",
+			expected: []string{"SELECT", "'\x95\x5c\\''", "FROM", "t"},
+		},
+		{
+			name:     "latin1",
+			charset:  CharsetLatin1,
+			input:    "SELECT * FROM caf\xe9_orders WHERE na\xefve = '\xe9'",
+			expected: []string{"SELECT", "*", "FROM", "caf\xe9_orders", "WHERE", "na\xefve", "=", "'\xe9'"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			lexer := NewLexer(Options{Charset: tt.charset})
+			lexer.Parse([]byte(tt.input))
+			lexer.Reset()
+			_, lexemes := lexTokens(lexer)
+			assert.DeepEqual(t, tt.expected, lexemes)
+			assert.NilError(t, lexer.Err())
+		})
+	}
+}
+
+func TestLexer_SQLMode(t *testing.T) {
+	tests := []struct {
+		name          string
+		mode          SQLMode
+		input         string
+		expected      []string
+		expectedTypes []TokenType
+	}{
+		{
+			name:          "Double quotes are strings by default",
+			input:         `SELECT "col" FROM t`,
+			expected:      []string{"SELECT", `"col"`, "FROM", "t"},
+			expectedTypes: []TokenType{TokenKeyword, TokenLiteral, TokenKeyword, TokenKeyword},
+		},
+		{
+			name:          "ANSI_QUOTES",
+			mode:          SQLModeANSIQuotes,
+			input:         `SELECT "col", "a""b" FROM t WHERE x = 'y'`,
+			expected:      []string{"SELECT", `"col"`, ",", `"a""b"`, "FROM", "t", "WHERE", "x", "=", "'y'"},
+			expectedTypes: []TokenType{TokenKeyword, TokenKeyword, TokenComma, TokenKeyword, TokenKeyword, TokenKeyword, TokenKeyword, TokenKeyword, TokenOperator, TokenLiteral},
+		},
+		{
+			name:          "Backslash escapes by default",
+			input:         `SELECT 'a\' b', 1`,
+			expected:      []string{"SELECT", `'a\' b'`, ",", "1"},
+			expectedTypes: []TokenType{TokenKeyword, TokenLiteral, TokenComma, TokenLiteral},
+		},
+		{
+			name:          "NO_BACKSLASH_ESCAPES",
+			mode:          SQLModeNoBackslashEscapes,
+			input:         `SELECT 'C:\dir\', 'it''s'`,
+			expected:      []string{"SELECT", `'C:\dir\'`, ",", `'it''s'`},
+			expectedTypes: []TokenType{TokenKeyword, TokenLiteral, TokenComma, TokenLiteral},
+		},
+		{
+			name:          "Doubled quotes",
+			input:         `SELECT 'string with ''quotes''', "say ""hi"""`,
+			expected:      []string{"SELECT", `'string with ''quotes'''`, ",", `"say ""hi"""`},
+			expectedTypes: []TokenType{TokenKeyword, TokenLiteral, TokenComma, TokenLiteral},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			l := NewLexer(Options{SQLMode: tt.mode})
+			l.Parse([]byte(tt.input))
+			tokens, lexemes := lexTokens(l)
+			assert.DeepEqual(t, tt.expected, lexemes)
+			assert.DeepEqual(t, tt.expectedTypes, tokenTypes(tokens))
+		})
+	}
+}
+
+func TestLexer_PipesAsConcat(t *testing.T) {
+	for _, mode := range []SQLMode{0, SQLModePipesAsConcat} {
+		l := NewLexer(Options{SQLMode: mode})
+		l.Parse([]byte("SELECT a || b"))
+		tokens := addIntoTokenSlice(nil, l)
+		if len(tokens) != 4 || tokens[2].Type != TokenOperator {
+			t.Fatalf("expected `||` operator, got %v", tokens)
+		}
+		isConcat := tokens[2].Attr&TokenAttrConcat != 0
+		if isConcat != (mode == SQLModePipesAsConcat) {
+			t.Errorf("sql_mode %d: expected concat %t, got %t", mode, mode == SQLModePipesAsConcat, isConcat)
+		}
+	}
+}
+
+func TestLexer_Errors(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected *Error
+	}{
+		{name: "valid", input: "SELECT 'a', `b`, /* c */ x'0F', b'1'", expected: nil},
+		{name: "string", input: "SELECT * FROM t WHERE a = 'abc", expected: &Error{ErrorUnterminatedString, 26}},
+		{name: "double quoted string", input: `SELECT "abc`, expected: &Error{ErrorUnterminatedString, 7}},
+		{name: "hex string", input: "SELECT x'0F", expected: &Error{ErrorUnterminatedString, 7}},
+		{name: "bit string", input: "SELECT b'01", expected: &Error{ErrorUnterminatedString, 7}},
+		{name: "comment", input: "SELECT 1 /* truncated", expected: &Error{ErrorUnterminatedComment, 9}},
+		{name: "executable comment", input: "SELECT /*!80000 1", expected: &Error{ErrorUnterminatedComment, 7}},
+		{name: "identifier", input: "SELECT `a` FROM `tab", expected: &Error{ErrorUnterminatedIdentifier, 16}},
+		{name: "comment inside string", input: "SELECT 'a /* b", expected: &Error{ErrorUnterminatedString, 7}},
+	}
+
+	lexer := NewLexer()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			lexer.Parse([]byte(tt.input))
+			lexer.Reset()
+			var last Token
+			for tok := lexer.NextToken(); tok.Type != TokenEOF; tok = lexer.NextToken() {
+				last = tok
+			}
+			if tt.expected == nil {
+				assert.NilError(t, lexer.Err())
+				return
+			}
+			assert.DeepEqual(t, tt.expected, lexer.Err())
+			assert.Equal(t, tt.expected.Offset, last.Pos.start)
+			assert.Equal(t, len(tt.input), last.Pos.end)
+			assert.Assert(t, last.Attr&TokenAttrUnterminated != 0)
+		})
+	}
+}
+
+func TestLexer_Position(t *testing.T) {
+	input := []byte("SELECT a,\n  b\r\nFROM `tàble`\n\nWHERE c = 'x'")
+	lexer := NewLexer()
+	lexer.Parse(input)
+	lexer.Reset()
+
+	var positions []string
+	for tok := lexer.NextToken(); tok.Type != TokenEOF; tok = lexer.NextToken() {
+		start, end := tok.Pos.Start(), tok.Pos.End()
+		assert.Equal(t, string(tok.LexemeRef(input)), string(input[start:end]))
+		positions = append(positions, string(input[start:end])+"@"+lexer.Position(start).String())
+	}
+	assert.DeepEqual(t, []string{
+		"SELECT@1:1", "a@1:8", ",@1:9",
+		"b@2:3",
+		"FROM@3:1", "`tàble`@3:6",
+		"WHERE@5:1", "c@5:7", "=@5:9", "'x'@5:11",
+	}, positions)
+
+	// offsets past the table name count characters, not bytes
+	assert.Equal(t, Position{Offset: 28, Line: 3, Column: 13}, lexer.Position(28))
+	assert.Equal(t, Position{Offset: len(input), Line: 5, Column: 14}, lexer.Position(len(input)+10))
+
+	lexer.Parse([]byte("\nSELECT"))
+	assert.Equal(t, Position{Offset: 1, Line: 2, Column: 1}, lexer.Position(1))
+}
+
+func TestLexer_Identifiers(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected []string
+		err      *Error
+	}{
+		{
+			name:     "underscore and dollar",
+			input:    "SELECT _id, price$usd, $total FROM t_1",
+			expected: []string{"SELECT", "_id", ",", "price$usd", ",", "$total", "FROM", "t_1"},
+		},
+		{
+			name:     "latin",
+			input:    "SELECT * FROM café_orders WHERE naïve=1",
+			expected: []string{"SELECT", "*", "FROM", "café_orders", "WHERE", "naïve", "=", "1"},
+		},
+		{
+			name:     "cjk",
+			input:    "SELECT 列1 FROM 表名.数据",
+			expected: []string{"SELECT", "列1", "FROM", "表名", ".", "数据"},
+		},
+		{
+			name:     "supplementary plane is not an identifier character",
+			input:    "SELECT a😀b",
+			expected: []string{"SELECT", "a", "b"},
+			err:      &Error{ErrorInvalidCharacter, 8},
+		},
+		{
+			name:     "replacement character",
+			input:    "SELECT a\uFFFDb",
+			expected: []string{"SELECT", "a\uFFFDb"},
+		},
+		{
+			name:     "invalid utf-8",
+			input:    "SELECT ab\xffcd, \xc3",
+			expected: []string{"SELECT", "ab", "cd", ","},
+			err:      &Error{ErrorInvalidUTF8, 9},
+		},
+	}
+
+	lexer := NewLexer()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			input := []byte(tt.input)
+			lexer.Parse(input)
+			lexer.Reset()
+			_, lexemes := lexTokens(lexer)
+			assert.DeepEqual(t, tt.expected, lexemes)
+			if tt.err == nil {
+				assert.NilError(t, lexer.Err())
+			} else {
+				assert.DeepEqual(t, tt.err, lexer.Err())
+			}
+		})
+	}
+}
+
+func TestLexer_Dialects(t *testing.T) {
+	const (
+		none = iota
+		nonReserved
+		reserved
+	)
+	tests := []struct {
+		word     string
+		expected map[Dialect]int
+	}{
+		{"select", map[Dialect]int{DialectMySQL57: reserved, DialectMySQL80: reserved, DialectMySQL84: reserved, DialectMariaDB10: reserved, DialectTiDB: reserved}},
+		{"split", map[Dialect]int{DialectMySQL80: none, DialectMariaDB11: none, DialectTiDB: nonReserved}},
+		{"tiflash", map[Dialect]int{DialectMySQL84: none, DialectTiDB: nonReserved}},
+		{"rank", map[Dialect]int{DialectMySQL57: none, DialectMySQL80: reserved, DialectMariaDB10: none, DialectTiDB: reserved}},
+		{"function", map[Dialect]int{DialectMySQL57: nonReserved, DialectMySQL80: reserved}},
+		{"qualify", map[Dialect]int{DialectMySQL80: none, DialectMySQL84: reserved}},
+		{"master_host", map[Dialect]int{DialectMySQL57: nonReserved, DialectMySQL80: nonReserved, DialectMySQL84: none}},
+		{"offset", map[Dialect]int{DialectMySQL80: nonReserved, DialectMariaDB10: reserved}},
+		{"sequence", map[Dialect]int{DialectMySQL80: none, DialectMariaDB10: nonReserved, DialectTiDB: nonReserved}},
+		{"vector", map[Dialect]int{DialectMariaDB10: none, DialectMariaDB11: nonReserved}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.word, func(t *testing.T) {
+			for dialect, expected := range tt.expected {
+				lexer := NewLexer(Options{Dialect: dialect})
+				lexer.Parse([]byte(tt.word))
+				tok := lexer.NextToken()
+				actual := none
+				if tok.IsBuiltInKeyword() {
+					actual = nonReserved
+					if tok.Attr&KeywordAttrReserved != 0 {
+						actual = reserved
+					}
+				}
+				assert.Equal(t, expected, actual, "dialect %d", dialect)
+			}
+		})
+	}
+}
+
+func TestLexer_BuiltInFunctions(t *testing.T) {
+	tests := []struct {
+		name      string
+		input     string
+		functions []string
+		builtIn   []string
+	}{
+		{
+			name:      "function and column",
+			input:     "SELECT count(*), count FROM t",
+			functions: []string{"count"},
+			builtIn:   []string{"SELECT", "count", "FROM"},
+		},
+		{
+			name:      "space before paren",
+			input:     "SELECT json_extract (doc, '$.a'), JSON_EXTRACT(doc, '$.b')",
+			functions: []string{"JSON_EXTRACT"},
+			builtIn:   []string{"SELECT", "JSON_EXTRACT"},
+		},
+		{
+			name:      "keyword functions",
+			input:     "SELECT LEFT(a, 1), IF(b, 1, 2) FROM t LEFT JOIN u",
+			functions: []string{"LEFT", "IF"},
+			builtIn:   []string{"SELECT", "LEFT", "IF", "FROM", "LEFT", "JOIN"},
+		},
+		{
+			name:      "insert values",
+			input:     "INSERT INTO t VALUES(1, DATE_FORMAT(NOW(), '%Y'))",
+			functions: []string{"DATE_FORMAT", "NOW"},
+			builtIn:   []string{"INSERT", "INTO", "VALUES", "DATE_FORMAT", "NOW"},
+		},
+		{
+			name:      "quoted name",
+			input:     "SELECT `count`(1)",
+			functions: nil,
+			builtIn:   []string{"SELECT"},
+		},
+		{
+			name:      "quoted keywords",
+			input:     "SELECT `select`, `from` FROM `where`",
+			functions: nil,
+			builtIn:   []string{"SELECT", "FROM"},
+		},
+	}
+
+	lexer := NewLexer()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			input := []byte(tt.input)
+			lexer.Parse(input)
+			lexer.Reset()
+			var functions, builtIn []string
+			for tok := lexer.NextToken(); tok.Type != TokenEOF; tok = lexer.NextToken() {
+				if tok.IsBuiltInFunction() {
+					functions = append(functions, string(tok.LexemeRef(input)))
+				}
+				if tok.IsBuiltInKeyword() {
+					builtIn = append(builtIn, string(tok.LexemeRef(input)))
+				}
+			}
+			assert.DeepEqual(t, tt.functions, functions)
+			assert.DeepEqual(t, tt.builtIn, builtIn)
+		})
+	}
+}
+
+func TestLexer_FunctionKinds(t *testing.T) {
+	input := []byte("SELECT SUM(a), ROW_NUMBER() OVER w, UPPER(b) FROM t")
+	lexer := NewLexer()
+	lexer.Parse(input)
+	var aggregate, window, reserved []string
+	for tok := lexer.NextToken(); tok.Type != TokenEOF; tok = lexer.NextToken() {
+		lexeme := string(tok.LexemeRef(input))
+		if tok.IsAggregateFunction() {
+			aggregate = append(aggregate, lexeme)
+		}
+		if tok.IsWindowFunction() {
+			window = append(window, lexeme)
+		}
+		if tok.IsReservedKeyword() {
+			reserved = append(reserved, lexeme)
+		}
+	}
+	assert.DeepEqual(t, []string{"SUM"}, aggregate)
+	assert.DeepEqual(t, []string{"ROW_NUMBER"}, window)
+	assert.DeepEqual(t, []string{"SELECT", "ROW_NUMBER", "OVER", "FROM"}, reserved)
+}
+
+func TestLexer_KeywordHash(t *testing.T) {
+	lists := [][]string{builtinFunctions, aggregateFunctions, windowFunctions}
+	for _, kw := range dialectKeywords {
+		lists = append(lists, kw.reserved, kw.nonReserved)
+	}
+	for _, list := range lists {
+		for _, w := range list {
+			if _, ok := keywords.lookup([]byte(w)); !ok {
+				t.Errorf("%s not found", w)
+			}
+			if _, ok := keywords.lookup([]byte(strings.ToLower(w))); !ok {
+				t.Errorf("%s not found in lower case", w)
+			}
+		}
+	}
+
+	for _, w := range []string{"", "users", "SELECTS", "SELEC", "select_", "_select", "x", strings.Repeat("A", 100)} {
+		if _, ok := keywords.lookup([]byte(w)); ok {
+			t.Errorf("%q found", w)
+		}
+	}
+}
+
+func TestLexer_ContextKeywords(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		keywords []string
+		dotted   []string
+	}{
+		{
+			name:     "qualified names",
+			input:    "SELECT p.select, db.order.id FROM `db`.t",
+			keywords: []string{"SELECT", "FROM"},
+			dotted:   []string{"p", "select", "db", "order", "id", "`db`", "t"},
+		},
+		{
+			name:     "non-reserved keywords as operands",
+			input:    "SELECT name, status FROM t WHERE status = 1 ORDER BY name DESC",
+			keywords: []string{"SELECT", "FROM", "WHERE", "ORDER", "BY", "DESC"},
+		},
+		{
+			name:     "non-reserved keywords as keywords",
+			input:    "SET SESSION sql_mode = ''; SHOW STATUS; SET NAMES utf8",
+			keywords: []string{"SET", "SESSION", "SHOW", "STATUS", "SET", "NAMES"},
+		},
+		{
+			name:     "cast type",
+			input:    "SELECT CAST(a AS DATE) AS date FROM t",
+			keywords: []string{"SELECT", "CAST", "AS", "DATE", "AS", "FROM"},
+		},
+		{
+			name:     "rollup",
+			input:    "SELECT a FROM t GROUP BY a WITH ROLLUP",
+			keywords: []string{"SELECT", "FROM", "GROUP", "BY", "WITH", "ROLLUP"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			input := []byte(tt.input)
+			lexer := NewLexer()
+			lexer.Parse(input)
+			lexer.Reset()
+			var keywords, dotted []string
+			for tok := lexer.NextToken(); tok.Type != TokenEOF; tok = lexer.NextToken() {
+				if tok.IsBuiltInKeyword() {
+					keywords = append(keywords, string(tok.LexemeRef(input)))
+				}
+				if tok.Attr&TokenKeywordIdentifierWithDot != 0 {
+					dotted = append(dotted, string(tok.LexemeRef(input)))
+				}
+			}
+			assert.DeepEqual(t, tt.keywords, keywords)
+			assert.DeepEqual(t, tt.dotted, dotted)
+		})
+	}
+}
+
+func TestLexer_Trivia(t *testing.T) {
+	input := []byte("SELECT  1 -- c\n{x}😀")
+	lexer := NewLexer(Options{Trivia: true})
+	lexer.Parse(input)
+	lexer.Reset()
+	tokens, lexemes := lexTokens(lexer)
+	assert.DeepEqual(t, []TokenType{
+		TokenKeyword, TokenWhitespace, TokenLiteral, TokenWhitespace, TokenComment, TokenWhitespace,
+		TokenUnknown, TokenKeyword, TokenUnknown, TokenUnknown,
+	}, tokenTypes(tokens))
+	assert.DeepEqual(t, []string{"SELECT", "  ", "1", " ", "-- c", "\n", "{", "x", "}", "😀"}, lexemes)
+}
+
+func FuzzLexer_RoundTrip(f *testing.F) {
+	for _, seed := range []string{
+		"SELECT * FROM users WHERE id = 1",
+		"SELECT a, b -- comment\nFROM t # another\r\n WHERE x IN (1, 2.5e3, 'it''s', \"q\\\"\")",
+		"/*!80000 SELECT */ /*+ NO_ICP(t) */ /* nested /* comment */ */ x",
+		"INSERT INTO `t``x` VALUES (_utf8mb4'a', N'b', X'0F', b'01', 0x1F, DATE '2024-01-01');",
+		"SET @a := @@session.sql_mode; SELECT a->>'$.b', :name, $1, ? FROM t;\t\n",
+		"DELIMITER //\nCREATE PROCEDURE p() BEGIN SELECT 1; END //\nDELIMITER ;\n",
+		"SELECT café, 名前 FROM t WHERE x = '\xff\xfe' AND \x00 {} [] \xc3",
+		"SELECT 'unterminated",
+	} {
+		f.Add(seed, uint8(CharsetUTF8MB4))
+		f.Add(seed, uint8(CharsetGBK))
+	}
+	f.Fuzz(func(t *testing.T, input string, charset uint8) {
+		sql := []byte(input)
+		lexer := NewLexer(Options{Trivia: true, Charset: Charset(charset % 5)})
+		lexer.Parse(sql)
+		lexer.Reset()
+		var out []byte
+		for tok := lexer.NextToken(); tok.Type != TokenEOF; tok = lexer.NextToken() {
+			if tok.Pos.start != len(out) {
+				t.Fatalf("token %v at %d, want %d", tok.Type, tok.Pos.start, len(out))
+			}
+			if tok.Pos.end <= tok.Pos.start {
+				t.Fatalf("empty token %v at %d", tok.Type, tok.Pos.start)
+			}
+			out = append(out, tok.LexemeRef(sql)...)
+		}
+		if string(out) != input {
+			t.Fatalf("round trip mismatch:\n got %q\nwant %q", out, input)
+		}
+	})
+}
+
 func BenchmarkLexer_SimpleQuery(b *testing.B) {
 	query := []byte("SELECT * FROM users WHERE id = 1")
 
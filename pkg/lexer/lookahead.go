@@ -0,0 +1,80 @@
+package lexer
+
+import "iter"
+
+// MaxPeek is how far ahead Peek can look.
+const MaxPeek = 16
+
+// Tokens returns an iterator over the tokens NextToken returns, up to
+// TokenEOF excluded.
+func (l *Lexer) Tokens() iter.Seq[Token] {
+	return func(yield func(Token) bool) {
+		for {
+			tok := l.NextToken()
+			if tok.Type == TokenEOF || !yield(tok) {
+				return
+			}
+		}
+	}
+}
+
+// Peek returns the token n positions ahead without consuming it, Peek(0)
+// being the token NextToken returns next. n must be less than MaxPeek.
+// Errors met by peeked tokens are reported by Err right away.
+func (l *Lexer) Peek(n int) Token {
+	if n < 0 || n >= MaxPeek {
+		panic("lexer: Peek beyond MaxPeek")
+	}
+	for l.peekLen <= n {
+		l.peeked[(l.peekHead+l.peekLen)%MaxPeek] = l.scan()
+		l.peekLen++
+	}
+	return l.peeked[(l.peekHead+n)%MaxPeek]
+}
+
+// Mark is a lexer position saved by Lexer.Mark.
+type Mark struct {
+	sql, suspended    []byte
+	curr, resumeAt    int
+	stmtStart         bool
+	prev              Token
+	accountAt         int
+	err               Error
+	peeked            [MaxPeek]Token
+	peekHead, peekLen int
+}
+
+// Mark saves the current position, to go back to with Rewind. Tokens
+// peeked so far are kept, so nothing is scanned twice until the lexer
+// moves past them. The client delimiter is not saved.
+func (l *Lexer) Mark() Mark {
+	return Mark{
+		sql:       l.sql,
+		suspended: l.suspended,
+		curr:      l.curr,
+		resumeAt:  l.resumeAt,
+		stmtStart: l.stmtStart,
+		prev:      l.prev,
+		accountAt: l.accountAt,
+		err:       l.err,
+		peeked:    l.peeked,
+		peekHead:  l.peekHead,
+		peekLen:   l.peekLen,
+	}
+}
+
+// Rewind goes back to m, a Mark of the current input: NextToken returns
+// the tokens following the mark again.
+func (l *Lexer) Rewind(m Mark) {
+	l.sql = m.sql
+	l.suspended = m.suspended
+	l.curr = m.curr
+	l.resumeAt = m.resumeAt
+	l.stmtStart = m.stmtStart
+	l.prev = m.prev
+	l.accountAt = m.accountAt
+	l.err = m.err
+	l.peeked = m.peeked
+	l.peekHead = m.peekHead
+	l.peekLen = m.peekLen
+}
@@ -0,0 +1,83 @@
+package lexer
+
+import (
+	"reflect"
+	"testing"
+
+	"gotest.tools/assert"
+)
+
+func TestLexer_Tokens(t *testing.T) {
+	input := []byte("SELECT a, b FROM t")
+	lexer := NewLexer()
+	lexer.Parse(input)
+	lexer.Reset()
+	var lexemes []string
+	for tok := range lexer.Tokens() {
+		lexemes = append(lexemes, string(lexer.GetLexeme(tok)))
+		if tok.Type == TokenComma {
+			break
+		}
+	}
+	assert.DeepEqual(t, []string{"SELECT", "a", ","}, lexemes)
+	for tok := range lexer.Tokens() {
+		lexemes = append(lexemes, string(lexer.GetLexeme(tok)))
+	}
+	assert.DeepEqual(t, []string{"SELECT", "a", ",", "b", "FROM", "t"}, lexemes)
+}
+
+func TestLexer_Peek(t *testing.T) {
+	input := []byte("SELECT a, b FROM t")
+	lexer := NewLexer()
+	lexer.Parse(input)
+	lexer.Reset()
+	expected := addIntoTokenSlice(nil, lexer)
+	lexer.Reset()
+
+	assert.Equal(t, expected[2], lexer.Peek(2))
+	assert.Equal(t, expected[0], lexer.Peek(0))
+	assert.Equal(t, TokenEOF, lexer.Peek(MaxPeek-1).Type)
+	for i := range expected {
+		assert.Equal(t, expected[i], lexer.Peek(0))
+		if i+1 < len(expected) {
+			assert.Equal(t, expected[i+1], lexer.Peek(1))
+		}
+		assert.Equal(t, expected[i], lexer.NextToken())
+	}
+	assert.Equal(t, TokenEOF, lexer.NextToken().Type)
+}
+
+func TestLexer_MarkRewind(t *testing.T) {
+	input := []byte("SELECT a, b FROM t WHERE c = 1")
+	lexer := NewLexer()
+	lexer.Parse(input)
+	lexer.Reset()
+	expected := addIntoTokenSlice(nil, lexer)
+	lexer.Reset()
+
+	lexer.NextToken()
+	lexer.Peek(3)
+	mark := lexer.Mark()
+	actual := addIntoTokenSlice(nil, lexer)
+	assert.Assert(t, reflect.DeepEqual(expected[1:], actual))
+
+	lexer.Rewind(mark)
+	actual = addIntoTokenSlice(nil, lexer)
+	assert.Assert(t, reflect.DeepEqual(expected[1:], actual))
+}
+
+func TestLexer_PeekExpandExecutableComment(t *testing.T) {
+	input := []byte("SELECT /*!80000 a */ b")
+	lexer := NewLexer()
+	lexer.Parse(input)
+	lexer.Reset()
+	lexer.NextToken()
+	comment := lexer.NextToken()
+	assert.Equal(t, "b", string(lexer.GetLexeme(lexer.Peek(0))))
+	lexer.ExpandExecutableComment(comment)
+	var lexemes []string
+	for tok := range lexer.Tokens() {
+		lexemes = append(lexemes, string(lexer.GetLexeme(tok)))
+	}
+	assert.DeepEqual(t, []string{"a", "b"}, lexemes)
+}
@@ -1,20 +1,56 @@
 package lexer
 
-var validOperators = map[string]struct{}{}
+var operators = []string{
+	">", ">=", "<", "<=",
+	"&", ">>", "<<", "^", "|", "~",
+	"<>", "!", "!=", "&&", "||",
+	"<=>",
+	"%", "+", "-", "*", "/",
+	"->", "->>",
+	":=", "=",
+}
+
+// operatorNode is a node of operatorTrie. next holds the index of the
+// child node for each ASCII byte, zero if there is none: the root is
+// never a child.
+type operatorNode struct {
+	next [128]uint8
+	end  bool
+}
+
+type operatorTrie []operatorNode
 
-// init valid operators
-func init() {
-	_validOperators := []string{
-		">", ">=", "<", "<=",
-		"&", ">>", "<<", "^", "|", "~",
-		"<>", "!", "!=", "&&", "||",
-		"<=>",
-		"%", "+", "-", "*", "/",
-		"->", "->>",
-		":=", "=",
-		"*",
+func newOperatorTrie(ops []string) operatorTrie {
+	t := operatorTrie{{}}
+	for _, op := range ops {
+		n := 0
+		for i := 0; i < len(op); i++ {
+			c := op[i]
+			if t[n].next[c] == 0 {
+				t = append(t, operatorNode{})
+				t[n].next[c] = uint8(len(t) - 1)
+			}
+			n = int(t[n].next[c])
+		}
+		t[n].end = true
 	}
-	for _, op := range _validOperators {
-		validOperators[op] = struct{}{}
+	return t
+}
+
+// match returns the length of the longest operator b starts with, or 0.
+func (t operatorTrie) match(b []byte) int {
+	longest := 0
+	n := 0
+	for i, c := range b {
+		if c >= 128 || t[n].next[c] == 0 {
+			break
+		}
+		n = int(t[n].next[c])
+		if t[n].end {
+			longest = i + 1
+		}
 	}
+	return longest
 }
+
+var operatorsTrie = newOperatorTrie(operators)
@@ -0,0 +1,44 @@
+package lexer
+
+// SQLMode holds the subset of the server's sql_mode that changes how
+// queries are tokenized.
+type SQLMode uint8
+
+const (
+	// `"` quotes identifiers instead of strings.
+	SQLModeANSIQuotes SQLMode = 1 << iota
+	// `\` is an ordinary character inside strings.
+	SQLModeNoBackslashEscapes
+	// `||` is string concatenation instead of logical OR.
+	SQLModePipesAsConcat
+)
+
+// Dialect selects the keyword set. The zero value is MySQL 8.0.
+type Dialect uint8
+
+const (
+	DialectMySQL80 Dialect = iota
+	DialectMySQL57
+	DialectMySQL84
+	DialectMariaDB10
+	DialectMariaDB11
+	DialectTiDB
+)
+
+type Options struct {
+	SQLMode SQLMode
+	Dialect Dialect
+	// Charset is the connection charset. With GBK, Big5 and SJIS, a
+	// multibyte character ending with the byte of `\` does not escape
+	// the quote after it, as on the server.
+	Charset Charset
+	// Trivia makes NextToken also return whitespace and the bytes that
+	// start no token, as TokenWhitespace and TokenUnknown, so the lexemes
+	// of all tokens put together are the input. Expanded executable
+	// comments are the exception.
+	Trivia bool
+}
+
+func (o Options) has(mode SQLMode) bool {
+	return o.SQLMode&mode != 0
+}
@@ -0,0 +1,48 @@
+package lexer
+
+import (
+	"sort"
+	"strconv"
+	"unicode/utf8"
+)
+
+// Position is a human readable location in the input. Line and Column
+// start at 1, and Column counts characters rather than bytes.
+type Position struct {
+	Offset int
+	Line   int
+	Column int
+}
+
+func (p Position) String() string {
+	return strconv.Itoa(p.Line) + ":" + strconv.Itoa(p.Column)
+}
+
+// Position converts a byte offset in the input given to Parse, such as
+// tok.Pos.Start() or Error.Offset, into a line and column.
+func (l *Lexer) Position(offset int) Position {
+	sql := l.sql
+	if l.suspended != nil {
+		sql = l.suspended
+	}
+	offset = min(max(offset, 0), len(sql))
+
+	if len(l.lines) == 0 {
+		l.lines = append(l.lines, 0)
+		for i, c := range sql {
+			if c == '\n' {
+				l.lines = append(l.lines, i+1)
+			}
+		}
+	}
+
+	line := sort.Search(len(l.lines), func(i int) bool {
+		return l.lines[i] > offset
+	})
+	lineStart := l.lines[line-1]
+	return Position{
+		Offset: offset,
+		Line:   line,
+		Column: utf8.RuneCount(sql[lineStart:offset]) + 1,
+	}
+}
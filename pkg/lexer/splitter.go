@@ -0,0 +1,132 @@
+package lexer
+
+import (
+	"github.com/bagaswh/mysql-toolkit/pkg/bytes"
+)
+
+var (
+	defaultDelimiter = []byte(";")
+	delimiterCommand = []byte("DELIMITER")
+)
+
+// SetDelimiter makes the lexer behave like the mysql client: occurrences
+// of delim outside strings, comments and quoted identifiers are returned
+// as TokenDelimiter, and `DELIMITER <new>` at the start of a statement is
+// returned as TokenDelimiterCommand and switches to the new delimiter.
+// A nil delim turns this off.
+func (l *Lexer) SetDelimiter(delim []byte) {
+	if delim == nil {
+		l.delimiter = nil
+		return
+	}
+	// copied, so the delimiter survives the input it was read from
+	l.delimiter = append(l.delimiter[:0], delim...)
+	l.stmtStart = true
+}
+
+func (l *Lexer) Delimiter() []byte {
+	return l.delimiter
+}
+
+// delimiterAt reports whether the client delimiter starts at offset i.
+func (l *Lexer) delimiterAt(i int) bool {
+	d := l.delimiter
+	return d != nil && i+len(d) <= len(l.sql) && string(l.sql[i:i+len(d)]) == string(d)
+}
+
+func (l *Lexer) clientDelimiter() Token {
+	rest := l.sql[l.curr:]
+	if l.delimiterAt(l.curr) {
+		l.curr += len(l.delimiter)
+		l.stmtStart = true
+		return Token{
+			Type: TokenDelimiter,
+			Pos:  Pos{l.start, l.curr},
+		}
+	}
+
+	if !l.stmtStart || len(rest) <= len(delimiterCommand) ||
+		!bytes.EqualFold(rest[:len(delimiterCommand)], delimiterCommand) ||
+		!isBlank(rest[len(delimiterCommand)]) {
+		return Token{}
+	}
+
+	// the new delimiter is the next word, the rest of the line is ignored
+	i := len(delimiterCommand)
+	for i < len(rest) && isBlank(rest[i]) {
+		i++
+	}
+	j := i
+	for j < len(rest) && !isWhiteSpace(rest[j]) {
+		j++
+	}
+	if i == j {
+		return Token{}
+	}
+	l.delimiter = append(l.delimiter[:0], rest[i:j]...)
+
+	k := j
+	for k < len(rest) && rest[k] != '\n' {
+		k++
+	}
+	l.curr += k
+	end := l.start + j
+	if l.opts.Trivia {
+		// keep the ignored rest of the line
+		end = l.curr
+	}
+	return Token{
+		Type: TokenDelimiterCommand,
+		Pos:  Pos{l.start, end},
+	}
+}
+
+// Statement is the byte range of one statement in a script, without its
+// delimiter and surrounding whitespace. Comments preceding the statement
+// are part of the range.
+type Statement struct {
+	Start, End int
+}
+
+// SplitStatements splits a script, such as a migration file or a
+// mysqldump, into statements, appending them to result. `;` is the initial
+// delimiter and DELIMITER commands are honored, so stored program bodies
+// written between `DELIMITER //` and `DELIMITER ;` stay intact. Segments
+// holding nothing but comments are dropped.
+func (l *Lexer) SplitStatements(sql []byte, result []Statement) []Statement {
+	l.Parse(sql)
+	l.Reset()
+	l.SetDelimiter(defaultDelimiter)
+	defer l.SetDelimiter(nil)
+
+	start, end := -1, -1
+	hasCode := false
+	for {
+		tok := l.NextToken()
+		switch tok.Type {
+		case TokenEOF, TokenDelimiter, TokenDelimiterCommand:
+			if hasCode {
+				result = append(result, Statement{Start: start, End: end})
+			}
+			if tok.Type == TokenEOF {
+				return result
+			}
+			start, end, hasCode = -1, -1, false
+			continue
+		}
+		if tok.IsTrivia() {
+			continue
+		}
+		if start < 0 {
+			start = tok.Pos.start
+		}
+		end = tok.Pos.end
+		if tok.Type != TokenComment {
+			hasCode = true
+		}
+	}
+}
+
+func isBlank(c byte) bool {
+	return c == ' ' || c == '\t'
+}
@@ -0,0 +1,124 @@
+package lexer
+
+import (
+	"testing"
+
+	"gotest.tools/assert"
+)
+
+func TestLexer_SplitStatements(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected []string
+	}{
+		{
+			name:     "simple",
+			input:    "SELECT 1; SELECT 2;",
+			expected: []string{"SELECT 1", "SELECT 2"},
+		},
+		{
+			name:     "trailing statement without delimiter",
+			input:    "SELECT 1;\nSELECT 2\n",
+			expected: []string{"SELECT 1", "SELECT 2"},
+		},
+		{
+			name:     "empty statements",
+			input:    ";; SELECT 1 ;;\n",
+			expected: []string{"SELECT 1"},
+		},
+		{
+			name:     "delimiter inside strings and identifiers",
+			input:    "SELECT 'a;b', \"c;d\", `e;f` FROM t; SELECT 2",
+			expected: []string{"SELECT 'a;b', \"c;d\", `e;f` FROM t", "SELECT 2"},
+		},
+		{
+			name:     "delimiter inside comments",
+			input:    "-- first; one\nSELECT 1 /* ; */; # trailing;\n",
+			expected: []string{"-- first; one\nSELECT 1 /* ; */"},
+		},
+		{
+			name:     "double dash without a space is no comment",
+			input:    "UPDATE t SET a=a--1;\nSELECT 2;",
+			expected: []string{"UPDATE t SET a=a--1", "SELECT 2"},
+		},
+		{
+			name:     "triple dash",
+			input:    "SELECT 1 ---c; SELECT 2",
+			expected: []string{"SELECT 1 ---c", "SELECT 2"},
+		},
+		{
+			name: "stored procedure",
+			input: "DELIMITER //\n" +
+				"CREATE PROCEDURE p()\nBEGIN\n  SELECT 1;\n  SELECT 2;\nEND//\n" +
+				"DELIMITER ;\n" +
+				"CALL p();\n",
+			expected: []string{
+				"CREATE PROCEDURE p()\nBEGIN\n  SELECT 1;\n  SELECT 2;\nEND",
+				"CALL p()",
+			},
+		},
+		{
+			name:  "dollar delimiter after a word",
+			input: "DELIMITER $$\nCREATE PROCEDURE p() BEGIN SELECT @a$$ END$$\nDELIMITER ;\nSELECT 2;",
+			expected: []string{
+				"CREATE PROCEDURE p() BEGIN SELECT @a",
+				"END",
+				"SELECT 2",
+			},
+		},
+		{
+			name: "mysqldump trigger",
+			input: "DELIMITER ;;\n" +
+				"/*!50003 CREATE TRIGGER tr BEFORE INSERT ON t FOR EACH ROW SET NEW.a = 1 */;;\n" +
+				"delimiter ;\n" +
+				"UNLOCK TABLES;\n",
+			expected: []string{
+				"/*!50003 CREATE TRIGGER tr BEFORE INSERT ON t FOR EACH ROW SET NEW.a = 1 */",
+				"UNLOCK TABLES",
+			},
+		},
+		{
+			name:     "delimiter word mid-statement",
+			input:    "SELECT delimiter FROM t; SELECT 1",
+			expected: []string{"SELECT delimiter FROM t", "SELECT 1"},
+		},
+	}
+
+	lexer := NewLexer()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			input := []byte(tt.input)
+			stmts := lexer.SplitStatements(input, nil)
+			actual := make([]string, 0, len(stmts))
+			for _, s := range stmts {
+				actual = append(actual, string(input[s.Start:s.End]))
+			}
+			assert.DeepEqual(t, tt.expected, actual)
+			assert.Assert(t, lexer.Delimiter() == nil)
+		})
+	}
+}
+
+func TestLexer_SetDelimiter(t *testing.T) {
+	input := []byte("SELECT 1 $$ DELIMITER ;\nSELECT 2;")
+	lexer := NewLexer()
+	lexer.Parse(input)
+	lexer.Reset()
+	lexer.SetDelimiter([]byte("$$"))
+
+	var types []TokenType
+	for {
+		tok := lexer.NextToken()
+		if tok.Type == TokenEOF {
+			break
+		}
+		types = append(types, tok.Type)
+	}
+	assert.DeepEqual(t, []TokenType{
+		TokenKeyword, TokenLiteral, TokenDelimiter,
+		TokenDelimiterCommand,
+		TokenKeyword, TokenLiteral, TokenDelimiter,
+	}, types)
+	assert.Equal(t, ";", string(lexer.Delimiter()))
+}
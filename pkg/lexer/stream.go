@@ -0,0 +1,193 @@
+package lexer
+
+import (
+	"errors"
+	"io"
+	"unicode/utf8"
+)
+
+const (
+	defaultStreamBufferSize = 64 * 1024
+	// Same as the default max_allowed_packet of MySQL 8.0.
+	defaultMaxTokenSize = 64 * 1024 * 1024
+	// the most bytes a scanner looks at past a token besides blanks, a
+	// word and the client delimiter, as in `1e+5` or `<=>`
+	streamLookahead = 4
+)
+
+var (
+	ErrTokenTooLong = errors.New("token too long")
+)
+
+// StreamLexer lexes SQL read from an io.Reader through a sliding window,
+// so memory stays bounded by the largest token rather than the input.
+// Token positions are absolute offsets in the stream. A token is only
+// returned once the window holds everything scanning it may look at, see
+// settled, and is otherwise scanned again with more input, so the tokens
+// are the same as Lexer gives for the whole input.
+type StreamLexer struct {
+	lex *Lexer
+	r   io.Reader
+
+	buf    []byte
+	base   int
+	maxTok int
+	eof    bool
+	err    error
+	lexErr Error
+
+	// lexer state to restore when a token is scanned again
+	delim []byte
+}
+
+func NewStreamLexer(r io.Reader, opts ...Options) *StreamLexer {
+	s := &StreamLexer{
+		lex:    NewLexer(opts...),
+		r:      r,
+		maxTok: defaultMaxTokenSize,
+	}
+	s.lex.Parse(nil)
+	s.lex.Reset()
+	return s
+}
+
+// Buffer sets the initial window and the maximum size the window may
+// grow to, like bufio.Scanner.Buffer. A token that does not fit in max
+// bytes stops the stream with ErrTokenTooLong. It must be called before
+// the first NextToken.
+func (s *StreamLexer) Buffer(buf []byte, max int) {
+	s.buf = buf[:0]
+	s.maxTok = max
+}
+
+// SetDelimiter is Lexer.SetDelimiter for the stream.
+func (s *StreamLexer) SetDelimiter(delim []byte) {
+	s.lex.SetDelimiter(delim)
+}
+
+// Err returns the first error other than io.EOF met while reading, or
+// else the first lexing error, as Lexer.Err does.
+func (s *StreamLexer) Err() error {
+	if s.err != nil {
+		return s.err
+	}
+	if s.lexErr.Kind == 0 {
+		return nil
+	}
+	err := s.lexErr
+	return &err
+}
+
+// Lexeme returns the bytes of tok, the last token returned by NextToken.
+// They are only valid until the next call to NextToken.
+func (s *StreamLexer) Lexeme(tok Token) []byte {
+	return s.buf[tok.Pos.start-s.base : tok.Pos.end-s.base]
+}
+
+func (s *StreamLexer) NextToken() Token {
+	l := s.lex
+	for s.err == nil {
+		at, stmtStart, lexErr, prev, accountAt := l.curr, l.stmtStart, l.err, l.prev, l.accountAt
+		hasDelim := l.delimiter != nil
+		s.delim = append(s.delim[:0], l.delimiter...)
+
+		tok := l.NextToken()
+		if tok.Type == TokenEOF {
+			// a NUL byte ends the input like it does for Lexer
+			if s.eof || l.curr < len(l.sql) {
+				s.keepLexErr()
+				return EOF
+			}
+		} else if s.eof || s.settled() {
+			s.keepLexErr()
+			tok.Pos.start += s.base
+			tok.Pos.end += s.base
+			return tok
+		}
+
+		// the token may continue past the window
+		l.curr, l.stmtStart, l.err, l.prev, l.accountAt = at, stmtStart, lexErr, prev, accountAt
+		if hasDelim {
+			l.delimiter = append(l.delimiter[:0], s.delim...)
+		}
+		s.fill()
+	}
+	return EOF
+}
+
+// keepLexErr keeps the first error met by the lexer, with its offset in
+// the stream.
+func (s *StreamLexer) keepLexErr() {
+	if s.lexErr.Kind == 0 && s.lex.err.Kind != 0 {
+		s.lexErr = s.lex.err
+		s.lexErr.Offset += s.base
+	}
+}
+
+// settled reports whether the window holds everything the lexer may have
+// looked at past the token just scanned, so more input cannot change it.
+// Past a token, scanners look over blanks and then at most a word, like
+// `FROM` after a column name, the client delimiter or streamLookahead
+// bytes. The word must end inside the window.
+func (s *StreamLexer) settled() bool {
+	l := s.lex
+	sql := l.sql
+	i := l.curr
+	for i < len(sql) && isWhiteSpace(sql[i]) {
+		i++
+	}
+	if len(sql)-i < max(streamLookahead, len(l.delimiter), len(s.delim)) {
+		return false
+	}
+	for i < len(sql) && (isIdentifierChar(sql[i]) || sql[i] >= utf8.RuneSelf) {
+		i++
+	}
+	return i < len(sql)
+}
+
+// fill drops the window before the current position and reads more input.
+// A word right before it stays, as the lexer may look it up again, see
+// Lexer.contextAfter.
+func (s *StreamLexer) fill() {
+	l := s.lex
+	keep := l.curr
+	if l.prev.Type == TokenKeyword {
+		keep = min(keep, l.prev.Pos.start)
+	}
+	if keep > 0 {
+		n := copy(s.buf, s.buf[keep:])
+		s.base += keep
+		s.buf = s.buf[:n]
+		l.curr -= keep
+		l.prev.Pos.start -= keep
+		l.prev.Pos.end -= keep
+		l.accountAt -= keep
+	}
+	if len(s.buf) == cap(s.buf) {
+		if len(s.buf) >= s.maxTok {
+			s.err = ErrTokenTooLong
+			return
+		}
+		size := defaultStreamBufferSize
+		if cap(s.buf) > 0 {
+			size = 2 * cap(s.buf)
+		}
+		buf := make([]byte, len(s.buf), min(size, s.maxTok))
+		copy(buf, s.buf)
+		s.buf = buf
+	}
+
+	for {
+		n, err := s.r.Read(s.buf[len(s.buf):cap(s.buf)])
+		s.buf = s.buf[:len(s.buf)+n]
+		if err == io.EOF {
+			s.eof = true
+		} else if err != nil {
+			s.err = err
+		}
+		if n > 0 || err != nil {
+			break
+		}
+	}
+	l.sql = s.buf
+}
@@ -0,0 +1,131 @@
+package lexer
+
+import (
+	"bytes"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+	"testing/iotest"
+
+	"gotest.tools/assert"
+)
+
+func TestStreamLexer(t *testing.T) {
+	var sb strings.Builder
+	for i := 0; i < 200; i++ {
+		sb.WriteString("SELECT `t`.`id`, _utf8mb4 'a;b', 0x1F, @@session.sql_mode /*!80000 ROLLUP */\n")
+		sb.WriteString("FROM t WHERE name = 'it''s' AND id IN (1, 2.5, ?) -- comment\n;\n")
+	}
+	sb.WriteString("DELIMITER //\nCREATE PROCEDURE p() BEGIN SELECT 1; END//\n")
+	input := []byte(sb.String())
+
+	lexer := NewLexer()
+	lexer.Parse(input)
+	lexer.Reset()
+	lexer.SetDelimiter([]byte(";"))
+	expected := addIntoTokenSlice(nil, lexer)
+
+	readers := map[string]func() *StreamLexer{
+		"default": func() *StreamLexer {
+			return NewStreamLexer(bytes.NewReader(input))
+		},
+		"one byte reads": func() *StreamLexer {
+			s := NewStreamLexer(iotest.OneByteReader(bytes.NewReader(input)))
+			s.Buffer(make([]byte, 600), 600)
+			return s
+		},
+		"half reads": func() *StreamLexer {
+			s := NewStreamLexer(iotest.HalfReader(bytes.NewReader(input)))
+			s.Buffer(make([]byte, 16), 1024)
+			return s
+		},
+	}
+	for name, newStream := range readers {
+		t.Run(name, func(t *testing.T) {
+			stream := newStream()
+			stream.SetDelimiter([]byte(";"))
+			var actual []Token
+			for {
+				tok := stream.NextToken()
+				if tok.Type == TokenEOF {
+					break
+				}
+				assert.Equal(t, string(input[tok.Pos.start:tok.Pos.end]), string(stream.Lexeme(tok)))
+				actual = append(actual, tok)
+			}
+			assert.NilError(t, stream.Err())
+			assert.Equal(t, len(expected), len(actual))
+			for i := range expected {
+				assert.Equal(t, expected[i], actual[i])
+			}
+		})
+	}
+}
+
+func TestStreamLexer_WindowEnd(t *testing.T) {
+	// with one byte reads, the window ends right after each byte
+	inputs := []string{
+		"SELECT _utf8mb4" + strings.Repeat(" ", 300) + "'x'",
+		"SELECT N'a', DATE '2024-01-01', _binary 0xFF, x'41'",
+		"CREATE USER 'app'@'localhost'; GRANT ALL ON *.* TO app@localhost; SET@x=1",
+		"SELECT a<=>b, a->>'$.c', a >> 2 FROM `t`.`u` WHERE name = 1",
+		"DELIMITER $$\nSELECT 1$$\nDELIMITER ;\nSELECT 2;",
+		"SELECT a--1, b-- c\n, status\n\n  FROM t WHERE é = 1.5e-3",
+	}
+	for _, input := range inputs {
+		lexer := NewLexer()
+		lexer.Parse([]byte(input))
+		lexer.Reset()
+		lexer.SetDelimiter([]byte(";"))
+		expected := addIntoTokenSlice(nil, lexer)
+
+		stream := NewStreamLexer(iotest.OneByteReader(strings.NewReader(input)))
+		stream.Buffer(make([]byte, 16), 4096)
+		stream.SetDelimiter([]byte(";"))
+		var actual []Token
+		for tok := stream.NextToken(); tok.Type != TokenEOF; tok = stream.NextToken() {
+			actual = append(actual, tok)
+		}
+		assert.NilError(t, stream.Err())
+		if !reflect.DeepEqual(expected, actual) {
+			t.Errorf("%q: got %v, want %v", input, actual, expected)
+		}
+	}
+}
+
+func TestStreamLexer_Errors(t *testing.T) {
+	t.Run("token too long", func(t *testing.T) {
+		input := "SELECT '" + strings.Repeat("x", 2048) + "'"
+		stream := NewStreamLexer(strings.NewReader(input))
+		stream.Buffer(make([]byte, 512), 1024)
+		assert.Equal(t, TokenKeyword, stream.NextToken().Type)
+		assert.Equal(t, TokenEOF, stream.NextToken().Type)
+		assert.Equal(t, ErrTokenTooLong, stream.Err())
+	})
+
+	t.Run("unterminated string", func(t *testing.T) {
+		input := strings.Repeat("SELECT 1;\n", 200) + "SELECT 'truncated"
+		stream := NewStreamLexer(iotest.OneByteReader(strings.NewReader(input)))
+		stream.Buffer(make([]byte, 600), 600)
+		for stream.NextToken().Type != TokenEOF {
+		}
+		assert.DeepEqual(t, &Error{ErrorUnterminatedString, len(input) - len("'truncated")}, stream.Err())
+	})
+
+	t.Run("truncated character", func(t *testing.T) {
+		input := strings.Repeat("SELECT 1;\n", 200) + "SELECT é\xf0\x9f"
+		stream := NewStreamLexer(iotest.OneByteReader(strings.NewReader(input)))
+		stream.Buffer(make([]byte, 600), 600)
+		for stream.NextToken().Type != TokenEOF {
+		}
+		assert.DeepEqual(t, &Error{ErrorInvalidUTF8, len(input) - 2}, stream.Err())
+	})
+
+	t.Run("read error", func(t *testing.T) {
+		errBroken := errors.New("broken")
+		stream := NewStreamLexer(iotest.ErrReader(errBroken))
+		assert.Equal(t, TokenEOF, stream.NextToken().Type)
+		assert.Equal(t, errBroken, stream.Err())
+	})
+}
@@ -14,6 +14,17 @@ const (
 	TokenLiteral
 	TokenBackslash
 	TokenOperator
+	TokenPlaceholder
+	TokenVariable
+	TokenExecutableComment
+	TokenOptimizerHint
+	TokenDelimiter
+	TokenDelimiterCommand
+	// TokenSemicolon is `;` when it is not the client delimiter.
+	TokenSemicolon
+	// Trivia, only returned with Options.Trivia.
+	TokenWhitespace
+	TokenUnknown
 	TokenEOF
 )
 
@@ -33,6 +44,24 @@ func (t TokenType) String() string {
 		return "TokenBackslash"
 	case TokenOperator:
 		return "TokenOperator"
+	case TokenPlaceholder:
+		return "TokenPlaceholder"
+	case TokenVariable:
+		return "TokenVariable"
+	case TokenExecutableComment:
+		return "TokenExecutableComment"
+	case TokenOptimizerHint:
+		return "TokenOptimizerHint"
+	case TokenDelimiter:
+		return "TokenDelimiter"
+	case TokenDelimiterCommand:
+		return "TokenDelimiterCommand"
+	case TokenSemicolon:
+		return "TokenSemicolon"
+	case TokenWhitespace:
+		return "TokenWhitespace"
+	case TokenUnknown:
+		return "TokenUnknown"
 	case TokenStar:
 		return "TokenStar"
 	case TokenComment:
@@ -50,9 +79,57 @@ type Attr uint64
 
 const (
 	TokenAttrBuiltIn Attr = 1 << iota
+	// TokenKeywordIdentifierWithDot marks a part of a qualified name, like
+	// `db` and `order` in `db.order`, which is never a keyword.
 	TokenKeywordIdentifierWithDot
+
+	// Placeholder kinds: positional `?`, named `:name` and numbered `$1`.
+	TokenAttrPlaceholderPositional
+	TokenAttrPlaceholderNamed
+	TokenAttrPlaceholderNumbered
+
+	// Variable kinds. System variables may carry an explicit scope.
+	TokenAttrUserVariable
+	TokenAttrSystemVariable
+	TokenAttrScopeGlobal
+	TokenAttrScopeSession
+	TokenAttrScopePersist
+	TokenAttrScopePersistOnly
+
+	// TokenAttrUnterminated is set on strings, quoted identifiers and
+	// comments that run to the end of input, see Lexer.Err.
+	TokenAttrUnterminated
+
+	// Literal prefixes: `_charset'...'`, `N'...'` and `DATE/TIME/TIMESTAMP '...'`.
+	TokenAttrLiteralIntroducer
+	TokenAttrLiteralNational
+	TokenAttrLiteralDate
+	TokenAttrLiteralTime
+	TokenAttrLiteralTimestamp
+
+	// TokenAttrConcat marks `||` under PIPES_AS_CONCAT.
+	TokenAttrConcat
+
+	// Literal kinds. TRUE, FALSE and NULL stay keywords but carry
+	// TokenAttrLiteralBoolean and TokenAttrLiteralNull.
+	TokenAttrLiteralInteger
+	TokenAttrLiteralDecimal
+	TokenAttrLiteralFloat
+	TokenAttrLiteralHex
+	TokenAttrLiteralBit
+	TokenAttrLiteralString
+	TokenAttrLiteralBoolean
+	TokenAttrLiteralNull
 )
 
+// TokenAttrLiteralKind masks the literal kind attributes.
+const TokenAttrLiteralKind = TokenAttrLiteralInteger | TokenAttrLiteralDecimal | TokenAttrLiteralFloat |
+	TokenAttrLiteralHex | TokenAttrLiteralBit | TokenAttrLiteralString | TokenAttrLiteralBoolean | TokenAttrLiteralNull
+
+// Executable comments keep their minimum server version (e.g. 80000 for
+// `/*!80000 ... */`) in the upper bits of Attr, see Token.MinVersion.
+const attrVersionShift = 40
+
 type Token struct {
 	Pos  Pos
 	Attr Attr
@@ -86,6 +163,54 @@ func (t Token) IsLiteral() bool {
 	return t.Type == TokenLiteral
 }
 
+// Introducer returns the charset name of a literal written with an
+// introducer, e.g. `utf8mb4` for `_utf8mb4'abc'`.
+func (t Token) Introducer(source []byte) []byte {
+	if t.Type != TokenLiteral || t.Attr&TokenAttrLiteralIntroducer == 0 || t.Pos.end > len(source) {
+		return nil
+	}
+	end := t.Pos.start + 1
+	for end < t.Pos.end && isWordChar(source[end]) {
+		end++
+	}
+	return source[t.Pos.start+1 : end]
+}
+
+func (t Token) IsPlaceholder() bool {
+	return t.Type == TokenPlaceholder
+}
+
+func (t Token) IsVariable() bool {
+	return t.Type == TokenVariable
+}
+
+func (t Token) IsUserVariable() bool {
+	return t.Type == TokenVariable && t.Attr&TokenAttrUserVariable != 0
+}
+
+func (t Token) IsSystemVariable() bool {
+	return t.Type == TokenVariable && t.Attr&TokenAttrSystemVariable != 0
+}
+
+func (t Token) IsComment() bool {
+	return t.Type == TokenComment || t.Type == TokenExecutableComment || t.Type == TokenOptimizerHint
+}
+
+// IsTrivia reports whether t is whitespace or unknown bytes, see
+// Options.Trivia.
+func (t Token) IsTrivia() bool {
+	return t.Type == TokenWhitespace || t.Type == TokenUnknown
+}
+
+// MinVersion returns the server version an executable comment requires,
+// or 0 when the comment is executed by every version.
+func (t Token) MinVersion() int {
+	if t.Type != TokenExecutableComment {
+		return 0
+	}
+	return int(t.Attr >> attrVersionShift)
+}
+
 func (t Token) IsBuiltInKeyword() bool {
 	return t.IsKeyword() && t.Attr&TokenAttrBuiltIn != 0
 }
@@ -98,6 +223,18 @@ func (t Token) IsBuiltInFunction() bool {
 	return t.IsKeyword() && t.Attr&KeywordAttrBuiltInFunction != 0
 }
 
+func (t Token) IsAggregateFunction() bool {
+	return t.IsKeyword() && t.Attr&KeywordAttrAggregateFunction != 0
+}
+
+func (t Token) IsWindowFunction() bool {
+	return t.IsKeyword() && t.Attr&KeywordAttrWindowFunction != 0
+}
+
+func (t Token) IsReservedKeyword() bool {
+	return t.IsKeyword() && t.Attr&KeywordAttrReserved != 0
+}
+
 func (t Token) IsQuotedWithBacktick(source []byte) bool {
 	if t.Pos.start >= 0 && t.Pos.end <= len(source) && t.Pos.end > t.Pos.start {
 		return source[t.Pos.start] == '`' && source[t.Pos.end-1] == '`'
@@ -121,10 +258,13 @@ func (t Token) LexemeWithBacktick(source []byte, result []byte) (int, []byte) {
 			return t.Lexeme(source, result)
 		}
 	}
-	n := copy(result, []byte{'`'})
-	n += copy(result[:n], source[t.Pos.start:t.Pos.end])
-	n += copy(result[:n], []byte{'`'})
-	return n, result[:n]
+	if len(result) < t.Pos.end-t.Pos.start+2 {
+		return 0, result
+	}
+	result[0] = '`'
+	n := 1 + copy(result[1:], source[t.Pos.start:t.Pos.end])
+	result[n] = '`'
+	return n + 1, result[:n+1]
 }
 
 func (t Token) IsBacktickAble() bool {
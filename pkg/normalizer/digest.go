@@ -0,0 +1,406 @@
+package normalizer
+
+import (
+	"github.com/bagaswh/mysql-toolkit/pkg/bytes"
+	"github.com/bagaswh/mysql-toolkit/pkg/lexer"
+)
+
+// digestKind is what a piece of DIGEST_TEXT stands for, as far as the
+// reductions of the server's digest are concerned.
+type digestKind byte
+
+const (
+	digestOther digestKind = iota
+	digestIdent
+	digestSign
+	digestComma
+	digestOpenParen
+	digestCloseParen
+	// `?`
+	digestValue
+	// `?, ...`
+	digestValueList
+	// `(?)`
+	digestRowSingle
+	// `(?) /* , ... */`
+	digestRowSingleList
+	// `(...)`
+	digestRowMultiple
+	// `(...) /* , ... */`
+	digestRowMultipleList
+)
+
+var digestTokens = [...][]byte{
+	digestValue:           []byte("?"),
+	digestValueList:       []byte("?, ..."),
+	digestRowSingle:       []byte("(?)"),
+	digestRowSingleList:   []byte("(?) /* , ... */"),
+	digestRowMultiple:     []byte("(...)"),
+	digestRowMultipleList: []byte("(...) /* , ... */"),
+}
+
+var (
+	spaceDotSpace   = []byte(" . ")
+	digestTruncated = []byte("...")
+)
+
+// The sizes the server's token array gives a token and an identifier,
+// which also takes its length and name.
+const (
+	digestTokenSize      = 2
+	digestIdentifierSize = 4
+	// performance_schema_max_digest_length
+	defaultMaxDigestLength = 1024
+)
+
+// digestFunctions are the function names the server lexes as keywords
+// when `(` follows, see sql_functions in the server's lex.h.
+var digestFunctions = [][]byte{
+	[]byte("ADDDATE"), []byte("BIT_AND"), []byte("BIT_OR"), []byte("BIT_XOR"),
+	[]byte("CAST"), []byte("COUNT"), []byte("CURDATE"), []byte("CURTIME"),
+	[]byte("DATE_ADD"), []byte("DATE_SUB"), []byte("EXTRACT"), []byte("GROUP_CONCAT"),
+	[]byte("JSON_ARRAYAGG"), []byte("JSON_OBJECTAGG"), []byte("MAX"), []byte("MID"),
+	[]byte("MIN"), []byte("NOW"), []byte("POSITION"), []byte("SESSION_USER"),
+	[]byte("STD"), []byte("STDDEV"), []byte("STDDEV_POP"), []byte("STDDEV_SAMP"),
+	[]byte("ST_COLLECT"), []byte("SUBDATE"), []byte("SUBSTR"), []byte("SUBSTRING"),
+	[]byte("SUM"), []byte("SYSDATE"), []byte("SYSTEM_USER"), []byte("TRIM"),
+	[]byte("VARIANCE"), []byte("VAR_POP"), []byte("VAR_SAMP"),
+}
+
+func isDigestKeyword(tok lexer.Token, lexeme []byte, dialect lexer.Dialect) bool {
+	if !tok.IsBuiltInKeyword() {
+		return false
+	}
+	if tok.IsBuiltInFunction() {
+		for _, fn := range digestFunctions {
+			if bytes.EqualFold(lexeme, fn) {
+				return true
+			}
+		}
+	}
+	return lexer.IsKeyword(lexeme, dialect)
+}
+
+type digestPiece struct {
+	kind  digestKind
+	start int
+	size  int
+}
+
+// digestWriter writes DIGEST_TEXT, remembering the last pieces written
+// so they can be reduced like the server does.
+type digestWriter struct {
+	result []byte
+	off    int
+	err    error
+
+	pieces       [16]digestPiece
+	top, npieces int
+
+	// the size the pieces take in the server's token array, which stops
+	// collecting tokens when it is full
+	size, maxSize int
+	full          bool
+}
+
+func (w *digestWriter) write(b []byte) {
+	if w.err != nil || w.full {
+		return
+	}
+	if len(b) > len(w.result)-w.off {
+		w.err = ErrBufferTooSmall
+		return
+	}
+	w.off += copy(w.result[w.off:], b)
+}
+
+func (w *digestWriter) writeByte(c byte) {
+	if w.err != nil || w.full {
+		return
+	}
+	if w.off == len(w.result) {
+		w.err = ErrBufferTooSmall
+		return
+	}
+	w.result[w.off] = c
+	w.off++
+}
+
+// begin starts a piece taking size bytes of the token array. Each piece
+// ends with a space, dropped at the end. Nothing more is written once the
+// token array is full.
+func (w *digestWriter) begin(kind digestKind, size int) {
+	if w.full || w.size+size > w.maxSize {
+		w.full = true
+		return
+	}
+	w.top = (w.top + 1) % len(w.pieces)
+	w.pieces[w.top] = digestPiece{kind: kind, start: w.off, size: size}
+	w.npieces = min(w.npieces+1, len(w.pieces))
+	w.size += size
+}
+
+// last returns the kind of the ith piece from the end, 0 being the last.
+func (w *digestWriter) last(i int) digestKind {
+	if i >= w.npieces {
+		return digestOther
+	}
+	return w.pieces[(w.top-i+len(w.pieces))%len(w.pieces)].kind
+}
+
+// drop removes the last n pieces.
+func (w *digestWriter) drop(n int) {
+	for i := range n {
+		w.size -= w.pieces[(w.top-i+len(w.pieces))%len(w.pieces)].size
+	}
+	w.off = w.pieces[(w.top-n+1+len(w.pieces))%len(w.pieces)].start
+	w.top = (w.top - n + len(w.pieces)) % len(w.pieces)
+	w.npieces -= n
+}
+
+// upper turns the last n bytes written to upper case.
+func (w *digestWriter) upper(n int) {
+	if w.err == nil && !w.full {
+		bytes.ToUpperInPlace(w.result[w.off-n : w.off])
+	}
+}
+
+func (w *digestWriter) piece(kind digestKind, b []byte) {
+	w.begin(kind, digestTokenSize)
+	w.write(b)
+	w.writeByte(' ')
+}
+
+func (w *digestWriter) value() {
+	// a sign is part of the value unless something it subtracts from
+	// precedes it
+	if w.last(0) == digestSign && !w.last(1).isOperand() {
+		w.drop(1)
+	}
+	if w.last(0) == digestComma && (w.last(1) == digestValue || w.last(1) == digestValueList) {
+		w.drop(2)
+		w.piece(digestValueList, digestTokens[digestValueList])
+		return
+	}
+	w.piece(digestValue, digestTokens[digestValue])
+}
+
+func (w *digestWriter) closeParen() {
+	var row digestKind
+	switch {
+	case w.last(0) == digestValue && w.last(1) == digestOpenParen:
+		row = digestRowSingle
+	case w.last(0) == digestValueList && w.last(1) == digestOpenParen:
+		row = digestRowMultiple
+	default:
+		w.piece(digestCloseParen, []byte(")"))
+		return
+	}
+	w.drop(2)
+	if w.last(0) == digestComma && (w.last(1) == row || w.last(1) == row+1) {
+		w.drop(2)
+		row++
+	}
+	w.piece(row, digestTokens[row])
+}
+
+// literalPrefix writes the introducer of `_binary'x'` or the type of
+// `DATE '2020-01-01'`, which are tokens of their own to the server, so the
+// literal is written `_binary ?` and `DATE ?`.
+func (w *digestWriter) literalPrefix(tok lexer.Token, lexeme, sql []byte) {
+	switch {
+	case tok.Attr&lexer.TokenAttrLiteralIntroducer != 0:
+		prefix := lexeme[:1+len(tok.Introducer(sql))]
+		w.begin(digestOther, digestIdentifierSize+len(prefix))
+		w.write(prefix)
+		w.writeByte(' ')
+	case tok.Attr&(lexer.TokenAttrLiteralDate|lexer.TokenAttrLiteralTime|lexer.TokenAttrLiteralTimestamp) != 0:
+		n := 0
+		for n < len(lexeme) && bytes.ToUpperByte(lexeme[n]) >= 'A' && bytes.ToUpperByte(lexeme[n]) <= 'Z' {
+			n++
+		}
+		w.piece(digestOther, lexeme[:n])
+		w.upper(n + 1)
+	}
+}
+
+func (k digestKind) isOperand() bool {
+	switch k {
+	case digestIdent, digestCloseParen, digestValue, digestRowSingle, digestRowMultiple:
+		return true
+	}
+	return false
+}
+
+// quoted writes name between backticks. quote is the quote name is
+// written with in the query, 0 if none.
+func (w *digestWriter) quoted(name []byte, quote byte) {
+	w.writeByte('`')
+	if quote == '`' || quote == 0 {
+		w.write(name)
+	} else {
+		for i := 0; i < len(name); i++ {
+			c := name[i]
+			if c == quote && i+1 < len(name) && name[i+1] == quote {
+				i++
+			}
+			if c == '`' {
+				w.writeByte('`')
+			}
+			w.writeByte(c)
+		}
+	}
+	w.writeByte('`')
+}
+
+func (w *digestWriter) identifier(lexeme []byte) {
+	name, quote := lexeme, byte(0)
+	if c := lexeme[0]; (c == '`' || c == '"') && len(lexeme) >= 2 && lexeme[len(lexeme)-1] == c {
+		name, quote = lexeme[1:len(lexeme)-1], c
+	}
+	w.begin(digestIdent, digestIdentifierSize+unquotedLen(name, quote))
+	w.quoted(name, quote)
+	w.writeByte(' ')
+}
+
+// unquotedLen returns the length of name, quoted with quote, once its
+// doubled quotes are undone.
+func unquotedLen(name []byte, quote byte) int {
+	n := len(name)
+	for i := 0; quote != 0 && i+1 < len(name); i++ {
+		if name[i] == quote && name[i+1] == quote {
+			n--
+			i++
+		}
+	}
+	return n
+}
+
+// variable writes `@name` as "@`name`" and `@@scope.name` as
+// "@@SCOPE . `name`", each `@`, the scope and the dot being tokens of
+// their own to the server.
+func (w *digestWriter) variable(tok lexer.Token, lexeme []byte) {
+	prefix := 1
+	if tok.IsSystemVariable() {
+		prefix = 2
+	}
+	name := lexeme[prefix:]
+	scope := -1
+	if tok.IsSystemVariable() {
+		for i, c := range name {
+			if c == '.' {
+				scope = i
+				break
+			}
+		}
+	}
+	size := prefix * digestTokenSize
+	if scope >= 0 {
+		size += 2 * digestTokenSize
+	}
+	quote := byte(0)
+	if rest := name[scope+1:]; len(rest) >= 2 && (rest[0] == '`' || rest[0] == '"' || rest[0] == '\'') && rest[len(rest)-1] == rest[0] {
+		quote = rest[0]
+		size += digestIdentifierSize + unquotedLen(rest[1:len(rest)-1], quote)
+	} else {
+		size += digestIdentifierSize + len(rest)
+	}
+	w.begin(digestIdent, size)
+
+	w.write(lexeme[:prefix])
+	if scope >= 0 {
+		w.write(name[:scope])
+		w.upper(scope)
+		w.write(spaceDotSpace)
+		name = name[scope+1:]
+	}
+	if len(name) == 0 {
+		// a truncated query, like `SELECT @`
+		w.writeByte(' ')
+		return
+	}
+	if quote != 0 {
+		w.quoted(name[1:len(name)-1], quote)
+	} else {
+		w.quoted(name, 0)
+	}
+	w.writeByte(' ')
+}
+
+// digestText writes the DIGEST_TEXT MySQL 8 gives the first statement of
+// sql in performance_schema. Literals and placeholders become `?`, lists
+// of them and of rows are collapsed, identifiers are backticked, keywords
+// are in upper case and tokens are separated by a space. Comments and
+// optimizer hints are dropped and executable comments expanded up to
+// config.ServerVersion. Like the server, the text stops with `...` when
+// its tokens no longer fit in config.MaxDigestLength bytes of the
+// server's token array.
+func digestText(config Config, lex *lexer.Lexer, sql []byte, result []byte) (int, []byte, error) {
+	lex.Parse(sql)
+	lex.Reset()
+	dialect := lex.Options().Dialect
+	w := digestWriter{result: result, maxSize: config.MaxDigestLength}
+	if w.maxSize == 0 {
+		w.maxSize = defaultMaxDigestLength
+	}
+	config.ExecutableComments = CommentExpand
+
+	for w.err == nil && !w.full {
+		tok := lex.NextToken()
+		if tok.Type == lexer.TokenEOF || tok.Type == lexer.TokenSemicolon || tok.Type == lexer.TokenDelimiter {
+			break
+		}
+		if tok.Type == lexer.TokenOptimizerHint {
+			continue
+		}
+		if isSkipped(config, lex, tok) {
+			continue
+		}
+
+		lexeme := tok.LexemeRef(sql)
+		switch {
+		case tok.Type == lexer.TokenLiteral:
+			if (lexeme[0] == '-' || lexeme[0] == '+') && w.last(0).isOperand() {
+				// `a -1` subtracts
+				w.piece(digestSign, lexeme[:1])
+			}
+			w.literalPrefix(tok, lexeme, sql)
+			w.value()
+		case tok.IsPlaceholder():
+			w.value()
+		case tok.IsVariable():
+			w.variable(tok, lexeme)
+		case tok.IsKeyword():
+			if isDigestKeyword(tok, lexeme, dialect) {
+				w.piece(digestOther, lexeme)
+				w.upper(len(lexeme) + 1)
+			} else {
+				w.identifier(lexeme)
+			}
+		case tok.Type == lexer.TokenOperator && len(lexeme) == 1 && (lexeme[0] == '-' || lexeme[0] == '+'):
+			w.piece(digestSign, lexeme)
+		case tok.Type == lexer.TokenComma:
+			w.piece(digestComma, lexeme)
+		case tok.Type == lexer.TokenOpenParen:
+			w.piece(digestOpenParen, lexeme)
+		case tok.Type == lexer.TokenCloseParen:
+			w.closeParen()
+		case tok.IsTrivia():
+		default:
+			w.piece(digestOther, lexeme)
+		}
+	}
+	if w.err != nil {
+		return w.off, result[:w.off], w.err
+	}
+	if w.full {
+		if len(result)-w.off < len(digestTruncated) {
+			return w.off, result[:w.off], ErrBufferTooSmall
+		}
+		w.off += copy(result[w.off:], digestTruncated)
+	} else if w.off > 0 {
+		// the last piece's trailing space
+		w.off--
+	}
+	return w.off, result[:w.off], nil
+}
@@ -0,0 +1,172 @@
+package normalizer
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/bagaswh/mysql-toolkit/pkg/lexer"
+)
+
+func TestNormalize_Digest(t *testing.T) {
+	lex := lexer.NewLexer()
+	config := Config{Format: FormatDigest}
+
+	// The expected texts are worked out from the server's sql_digest.cc
+	// and the examples in the reference manual, not captured from a
+	// running server.
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{
+			name:     "client version query",
+			input:    "select @@version_comment limit 1",
+			expected: "SELECT @@`version_comment` LIMIT ?",
+		},
+		{
+			name:     "comparisons",
+			input:    "SELECT * FROM orders WHERE customer_id=10 AND quantity>20",
+			expected: "SELECT * FROM `orders` WHERE `customer_id` = ? AND `quantity` > ?",
+		},
+		{
+			name:     "in list and limit",
+			input:    "SELECT a FROM db.t WHERE id IN (1, 2, 3) LIMIT 10, 20",
+			expected: "SELECT `a` FROM `db` . `t` WHERE `id` IN (...) LIMIT ?, ...",
+		},
+		{
+			name:     "single value in parens",
+			input:    "SELECT SLEEP(1) FROM t WHERE id IN (?)",
+			expected: "SELECT `SLEEP` (?) FROM `t` WHERE `id` IN (?)",
+		},
+		{
+			name:     "multi-row insert",
+			input:    "INSERT INTO t (a, b) VALUES (1, 'x'), (2, 'y'), (3, 'z')",
+			expected: "INSERT INTO `t` ( `a` , `b` ) VALUES (...) /* , ... */",
+		},
+		{
+			name:     "single-column insert",
+			input:    "INSERT INTO t VALUES (1), (2)",
+			expected: "INSERT INTO `t` VALUES (?) /* , ... */",
+		},
+		{
+			name:     "function keywords",
+			input:    "SELECT COUNT(*), concat(a, b) FROM t GROUP BY a",
+			expected: "SELECT COUNT ( * ) , `concat` ( `a` , `b` ) FROM `t` GROUP BY `a`",
+		},
+		{
+			name:     "signs",
+			input:    "SELECT -1, a -1, a - +1 FROM t WHERE b = -(2)",
+			expected: "SELECT ? , `a` - ? , `a` - ? FROM `t` WHERE `b` = - (?)",
+		},
+		{
+			name:     "quoted identifiers",
+			input:    "SELECT `a``b`, `c` FROM `t`",
+			expected: "SELECT `a``b` , `c` FROM `t`",
+		},
+		{
+			name:     "variables",
+			input:    "SET @@session.sql_mode = 'x', @a := 1, @`b` = 2",
+			expected: "SET @@SESSION . `sql_mode` = ? , @`a` := ? , @`b` = ?",
+		},
+		{
+			name:     "comments and executable comments",
+			input:    "SELECT /*+ BKA(t) */ /*!80000 x, */ y FROM t -- c",
+			expected: "SELECT `x` , `y` FROM `t`",
+		},
+		{
+			name:     "truncated user variable",
+			input:    "SELECT @",
+			expected: "SELECT @",
+		},
+		{
+			name:     "truncated system variable",
+			input:    "SELECT @@",
+			expected: "SELECT @@",
+		},
+		{
+			name:     "introducers and typed literals",
+			input:    "SELECT a = _binary'x', b = _utf8mb4 0x41, c = N'y', d = DATE '2020-01-01', e = time'10:00'",
+			expected: "SELECT `a` = _binary ? , `b` = _utf8mb4 ? , `c` = ? , `d` = DATE ? , `e` = TIME ?",
+		},
+		{
+			name:     "arithmetic",
+			input:    "SELECT 5-3 AS a, 1e+5 AS b, 2.-1 AS c, a*-1",
+			expected: "SELECT ? - ? AS `a` , ? AS `b` , ? - ? AS `c` , `a` * ?",
+		},
+		{
+			name:     "first statement only",
+			input:    "SELECT 1; SELECT 2",
+			expected: "SELECT ?",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			result := make([]byte, len(tc.input)*3)
+			_, normalized, err := Normalize(config, lex, []byte(tc.input), result)
+			if err != nil {
+				t.Errorf("Normalize() error = %v", err)
+				return
+			}
+			if actual := string(normalized); actual != tc.expected {
+				t.Errorf("got %q, want %q", actual, tc.expected)
+			}
+		})
+	}
+}
+
+func TestNormalize_DigestTruncated(t *testing.T) {
+	tests := []struct {
+		name      string
+		maxLength int
+		input     string
+		expected  string
+	}{
+		{
+			name:      "identifiers fill the token array",
+			maxLength: 20,
+			input:     "SELECT a, b FROM t",
+			expected:  "SELECT `a` , `b` FROM ...",
+		},
+		{
+			name:      "fits exactly",
+			maxLength: 21,
+			input:     "SELECT a, b FROM t",
+			expected:  "SELECT `a` , `b` FROM `t`",
+		},
+		{
+			name:      "collapsed list frees its tokens",
+			maxLength: 14,
+			input:     "SELECT 1 IN (1, 2, 3, 4) FROM t",
+			expected:  "SELECT ? IN (...) FROM ...",
+		},
+		{
+			name:     "default length",
+			input:    "SELECT " + strings.Repeat("c, ", 200) + "d FROM t",
+			expected: "SELECT " + strings.Repeat("`c` , ", 146) + "...",
+		},
+	}
+
+	lex := lexer.NewLexer()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := Config{Format: FormatDigest, MaxDigestLength: tt.maxLength}
+			_, got, err := Normalize(config, lex, []byte(tt.input), make([]byte, 4096))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(got) != tt.expected {
+				t.Errorf("Normalize() = %q, want %q", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestNormalize_DigestBufferTooSmall(t *testing.T) {
+	input := []byte("SELECT a FROM t WHERE id IN (1, 2, 3)")
+	_, _, err := Normalize(Config{Format: FormatDigest}, lexer.NewLexer(), input, make([]byte, 20))
+	if err != ErrBufferTooSmall {
+		t.Errorf("got %v, want %v", err, ErrBufferTooSmall)
+	}
+}
@@ -0,0 +1,194 @@
+package normalizer
+
+import (
+	"crypto/md5"
+	"encoding/hex"
+	"regexp"
+	"strings"
+)
+
+// The regular expressions of pt-query-digest's QueryRewriter::fingerprint,
+// in the order it applies them.
+var (
+	ptMysqldump      = regexp.MustCompile("\\ASELECT /\\*!40001 SQL_NO_CACHE \\*/ \\* FROM `")
+	ptToolkit        = regexp.MustCompile(`/\*\w+\.\w+:[0-9]/[0-9]\*/`)
+	ptAdminCommand   = regexp.MustCompile(`\Aadministrator command: `)
+	ptCall           = regexp.MustCompile(`(?i)\A\s*(call\s+\S+)\(`)
+	ptMultiRowInsert = regexp.MustCompile(`(?is)\A((?:INSERT|REPLACE)(?: IGNORE)?\s+INTO.+?VALUES\s*\(.*?\))\s*,\s*\(`)
+	ptLineComment    = regexp.MustCompile(`(?:--|#)[^'"\r\n]*`)
+	ptBlockComment   = regexp.MustCompile(`(?s)/\*[^!].*?\*/`)
+	ptUse            = regexp.MustCompile(`(?i)\Ause \S+(\n?)\z`)
+	ptEscapedQuote   = regexp.MustCompile(`\\["']`)
+	ptDoubleQuoted   = regexp.MustCompile(`(?s)".*?"`)
+	ptSingleQuoted   = regexp.MustCompile(`(?s)'.*?'`)
+	ptNumber         = regexp.MustCompile(`\b[0-9+-][0-9a-f.xb+-]*`)
+	ptNumberPrefix   = regexp.MustCompile(`[xb.+-]\?`)
+	ptLeadingSpace   = regexp.MustCompile(`\A\s+`)
+	ptSpaces         = regexp.MustCompile(`[ \n\t\r\f]+`)
+	ptNull           = regexp.MustCompile(`\bnull\b`)
+	ptValueLists     = regexp.MustCompile(`\b(in|values?)(?:[\s,]*\([\s?,]*\))+`)
+	ptLimit          = regexp.MustCompile(`\blimit \?(?:, ?\?| offset \?)?`)
+	ptOrderBy        = regexp.MustCompile(`(?i)\border by `)
+	ptAsc            = regexp.MustCompile(`(?i)\A(.+?)\s+ASC`)
+)
+
+// fingerprint is a port of pt-query-digest's fingerprint with its default
+// options. It works on the text like the original, so its quirks, such as
+// `0xFF` becoming `?ff`, are kept for the fingerprints to stay the same.
+func fingerprint(query string) string {
+	switch {
+	case ptMysqldump.MatchString(query):
+		return "mysqldump"
+	case ptToolkit.MatchString(query):
+		return "percona-toolkit"
+	case ptAdminCommand.MatchString(query):
+		return query
+	}
+	if m := ptCall.FindStringSubmatch(query); m != nil {
+		return strings.ToLower(m[1])
+	}
+	if m := ptMultiRowInsert.FindStringSubmatch(query); m != nil {
+		query = m[1]
+	}
+
+	// a line comment only goes if no quote is before the end of the line
+	query = removeLineComments(query)
+	query = ptBlockComment.ReplaceAllString(query, "")
+	if ptUse.MatchString(query) {
+		return ptUse.ReplaceAllString(query, "use ?$1")
+	}
+
+	query = ptEscapedQuote.ReplaceAllString(query, "")
+	query = ptDoubleQuoted.ReplaceAllString(query, "?")
+	query = ptSingleQuoted.ReplaceAllString(query, "?")
+	query = ptNumber.ReplaceAllString(query, "?")
+	query = ptNumberPrefix.ReplaceAllString(query, "?")
+
+	query = ptLeadingSpace.ReplaceAllString(query, "")
+	query = strings.TrimSuffix(query, "\n")
+	query = ptSpaces.ReplaceAllString(query, " ")
+	query = strings.ToLower(query)
+	query = ptNull.ReplaceAllString(query, "?")
+	query = ptValueLists.ReplaceAllString(query, "$1(?+)")
+	query = collapseUnion(query)
+	if loc := ptLimit.FindStringIndex(query); loc != nil {
+		query = query[:loc[0]] + "limit ?" + query[loc[1]:]
+	}
+	return removeOrderByAsc(query)
+}
+
+func removeLineComments(query string) string {
+	var b strings.Builder
+	last := 0
+	for _, loc := range ptLineComment.FindAllStringIndex(query, -1) {
+		if end := loc[1]; end < len(query) && query[end] != '\r' && query[end] != '\n' {
+			continue
+		}
+		b.WriteString(query[last:loc[0]])
+		last = loc[1]
+	}
+	if last == 0 {
+		return query
+	}
+	b.WriteString(query[last:])
+	return b.String()
+}
+
+func isPerlWordChar(c byte) bool {
+	return c == '_' || c >= '0' && c <= '9' || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z'
+}
+
+func isPerlSpace(c byte) bool {
+	return c == ' ' || c == '\t' || c == '\n' || c == '\r' || c == '\f' || c == '\v'
+}
+
+// collapseUnion replaces `select ... union select ...`, where the same
+// select repeats, with `select ... /*repeat union*/`, as the original
+// `\b(select\s.*?)(?:(\sunion(?:\sall)?)\s\1)+` does.
+func collapseUnion(query string) string {
+	var b strings.Builder
+	last := 0
+	for p := 0; p < len(query); p++ {
+		if p > 0 && isPerlWordChar(query[p-1]) || !strings.HasPrefix(query[p:], "select") ||
+			p+len("select") >= len(query) || !isPerlSpace(query[p+len("select")]) {
+			continue
+		}
+		for e := p + len("select "); e <= len(query); e++ {
+			end, union := unionRepeats(query, e, query[p:e])
+			if end < 0 {
+				continue
+			}
+			b.WriteString(query[last:e])
+			b.WriteString(" /*repeat")
+			b.WriteString(union)
+			b.WriteString("*/")
+			last = end
+			p = end - 1
+			break
+		}
+	}
+	if last == 0 {
+		return query
+	}
+	b.WriteString(query[last:])
+	return b.String()
+}
+
+// unionRepeats matches one or more `\sunion(?:\sall)?\s` followed by
+// sel at i, returning the end of the match and the last union, or -1.
+func unionRepeats(query string, i int, sel string) (int, string) {
+	end, union := -1, ""
+	for {
+		if i >= len(query) || !isPerlSpace(query[i]) || !strings.HasPrefix(query[i+1:], "union") {
+			return end, union
+		}
+		k := i + len(" union")
+		matched := false
+		for _, all := range []bool{true, false} {
+			j := k
+			if all {
+				if j >= len(query) || !isPerlSpace(query[j]) || !strings.HasPrefix(query[j+1:], "all") {
+					continue
+				}
+				j += len(" all")
+			}
+			if j < len(query) && isPerlSpace(query[j]) && strings.HasPrefix(query[j+1:], sel) {
+				union = query[i:j]
+				i = j + 1 + len(sel)
+				end = i
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			return end, union
+		}
+	}
+}
+
+// removeOrderByAsc drops each ASC after ORDER BY, as the original
+// `s/\G(.+?)\s+ASC/$1/gi` does: it is matched without a word boundary.
+func removeOrderByAsc(query string) string {
+	loc := ptOrderBy.FindStringIndex(query)
+	if loc == nil {
+		return query
+	}
+	pos := loc[1]
+	for {
+		m := ptAsc.FindStringSubmatchIndex(query[pos:])
+		if m == nil {
+			return query
+		}
+		kept := query[pos : pos+m[3]]
+		query = query[:pos] + kept + query[pos+m[1]:]
+		pos += len(kept)
+	}
+}
+
+// FingerprintChecksum returns the checksum pt-query-digest identifies a
+// query by from its fingerprint, see FormatFingerprint: the last 16 hex
+// digits of the MD5 of the fingerprint, in upper case.
+func FingerprintChecksum(fingerprint []byte) string {
+	sum := md5.Sum(fingerprint)
+	return strings.ToUpper(hex.EncodeToString(sum[8:]))
+}
@@ -0,0 +1,64 @@
+package normalizer
+
+import (
+	"testing"
+
+	"github.com/bagaswh/mysql-toolkit/pkg/lexer"
+)
+
+func TestNormalize_Fingerprint(t *testing.T) {
+	lex := lexer.NewLexer()
+
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{"strings", "SELECT * FROM db.tbl WHERE col='foo'", "select * from db.tbl where col=?"},
+		{"double quoted strings", "select 'hello', \"hello\", 'it\\'s'\n", "select ?, ?, ?"},
+		{"numbers", "select 0e0, +6e-30, -6.00 from foo where a = 5.5 or b=0.5 or c=.5", "select ?, ?, ? from foo where a = ? or b=? or c=?"},
+		{"hex and bits", "select 0x0, x'123', 0b1010, b'10101' from foo", "select ?, ?, ?, ? from foo"},
+		{"numbers in identifiers", "select * from db1.t2 where a=-1 and b=0xFF", "select * from db1.t2 where a=? and b=?ff"},
+		{"null", "SELECT * FROM a WHERE id IS NULL", "select * from a where id is ?"},
+		{"whitespace", "  select *\n\tfrom  foo\n", "select * from foo"},
+		{"limit", "select * from foo limit 5", "select * from foo limit ?"},
+		{"limit with offset", "select * from foo limit 5, 10", "select * from foo limit ?"},
+		{"limit offset", "select * from foo limit 5 offset 10", "select * from foo limit ?"},
+		{"in list", "select * from foo where id in (1, 2, 3)", "select * from foo where id in(?+)"},
+		{"values", "insert into foo(a, b, c) values(2, 4, 5)", "insert into foo(a, b, c) values(?+)"},
+		{"value", "insert into foo(a, b, c) value(2, 4, 5)", "insert into foo(a, b, c) value(?+)"},
+		{"multi-row insert", "INSERT INTO t (a,b) VALUES (1,'x'),(2,'y')", "insert into t (a,b) values(?+)"},
+		{"order by asc", "select * from tbl where id=1 order by col1 asc, col2 ASC", "select * from tbl where id=? order by col1, col2"},
+		{"union", "SELECT a FROM t UNION ALL SELECT a FROM t UNION SELECT a FROM t", "select a from t /*repeat union*/"},
+		{"comments", "select * from t -- it's\n# x\nwhere /* c */ a=1", "select * from t -- it's where a=?"},
+		{"executable comment", "SELECT /*!40001 SQL_NO_CACHE */ * FROM t", "select /*!? sql_no_cache */ * from t"},
+		{"call", "CALL foo(1, 2, 3)", "call foo"},
+		{"use", "use `foo`", "use ?"},
+		{"administrator command", "administrator command: Init DB", "administrator command: Init DB"},
+		{"mysqldump", "SELECT /*!40001 SQL_NO_CACHE */ * FROM `t`", "mysqldump"},
+		{"percona toolkit", "REPLACE /*foo.bar:3/3*/ INTO checksum.checksum", "percona-toolkit"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			result := make([]byte, len(tc.input)*3)
+			_, normalized, err := Normalize(PTQueryDigest, lex, []byte(tc.input), result)
+			if err != nil {
+				t.Errorf("Normalize() error = %v", err)
+				return
+			}
+			if actual := string(normalized); actual != tc.expected {
+				t.Errorf("got %q, want %q", actual, tc.expected)
+			}
+		})
+	}
+}
+
+func TestFingerprintChecksum(t *testing.T) {
+	// md5("select * from foo where a = ?") = b993a948ee7f5d71be3ec070d8756e8b
+	got := FingerprintChecksum([]byte("select * from foo where a = ?"))
+	want := "BE3EC070D8756E8B"
+	if got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
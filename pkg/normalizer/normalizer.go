@@ -2,6 +2,7 @@ package normalizer
 
 import (
 	"errors"
+	"unicode/utf8"
 
 	"github.com/bagaswh/mysql-toolkit/pkg/bytes"
 	"github.com/bagaswh/mysql-toolkit/pkg/lexer"
@@ -15,11 +16,83 @@ const (
 	CaseUpper
 )
 
+type CommentMode byte
+
+const (
+	CommentKeep CommentMode = iota
+	CommentStrip
+	// CommentExpand inlines the content of executable comments the target
+	// server would run (see Config.ServerVersion) and strips the others.
+	// Optimizer hints cannot be expanded and are kept.
+	CommentExpand
+)
+
+type IdentifierQuoting byte
+
+const (
+	// QuoteKeep writes identifiers as they are written.
+	QuoteKeep IdentifierQuoting = iota
+	// QuoteAlways puts every identifier between backticks.
+	QuoteAlways
+	// QuoteNever removes the quotes around identifiers, even when the
+	// name no longer parses without them.
+	QuoteNever
+	// QuoteWhenNeeded backticks only the identifiers that need it: reserved
+	// words of the lexer's dialect and names that are empty, start with a
+	// digit or have characters other than letters, digits, `_` and `$`.
+	QuoteWhenNeeded
+)
+
+type Format byte
+
+const (
+	FormatDefault Format = iota
+	// FormatDigest writes the DIGEST_TEXT of performance_schema statement
+	// digests. Only ServerVersion and MaxDigestLength apply with it.
+	FormatDigest
+	// FormatFingerprint writes the fingerprint of pt-query-digest, see
+	// FingerprintChecksum. No other option applies with it.
+	FormatFingerprint
+)
+
+// PTQueryDigest fingerprints queries like Percona's pt-query-digest.
+var PTQueryDigest = Config{Format: FormatFingerprint}
+
 type Config struct {
+	Format Format
+	// KeywordCase applies to keywords and IdentifierCase to the names of
+	// tables, columns and the like. CaseDefault keeps the case written.
 	KeywordCase    Case
-	RemoveLiterals bool
-	// PutBacktickOnKeywords    bool
-	// RemoveBacktickOnKeywords bool
+	IdentifierCase Case
+	// LowerCaseTableNames is the server's lower_case_table_names. Under 1
+	// and 2 names compare case-insensitively, so identifiers are written in
+	// lower case unless IdentifierCase is set. Under 0 table names are case
+	// sensitive, and identifiers keep their case by default.
+	LowerCaseTableNames int
+	RemoveLiterals      bool
+	// How `/*!NNNNN ... */` executable comments and `/*+ ... */` optimizer
+	// hints are emitted. Regular comments are always dropped.
+	ExecutableComments CommentMode
+	OptimizerHints     CommentMode
+	// ServerVersion is the target version for CommentExpand, in the
+	// executable comment format (80034 for 8.0.34). Zero expands every
+	// executable comment.
+	ServerVersion int
+	// MaxDigestLength is performance_schema_max_digest_length for
+	// FormatDigest. Zero is the server's default of 1024.
+	MaxDigestLength int
+	// CollapseUserVariables replaces user variable names with `@?`,
+	// so `@a` and `@b` normalize the same. System variables are kept.
+	CollapseUserVariables bool
+	// CollapseInLists writes `IN (...)` for an IN list made only of
+	// literals and placeholders, whatever their number.
+	CollapseInLists bool
+	// CollapseValues writes `VALUES (?+)` for the rows of VALUES when they
+	// are made only of literals and placeholders, whatever their number.
+	CollapseValues bool
+	// IdentifierQuoting applies to backticked names, names quoted with `"`
+	// under ANSI_QUOTES, and words the lexer takes for identifiers.
+	IdentifierQuoting IdentifierQuoting
 	// PutSpaceBeforeOpenParen bool
 }
 
@@ -37,7 +110,7 @@ func isSpaceAble(config Config, prev lexer.Token, token lexer.Token) bool {
 	if prev.IsKeyword() && token.Type == lexer.TokenOpenParen {
 		return false
 	}
-	if token.Type == lexer.TokenComma || token.Type == lexer.TokenCloseParen {
+	if token.Type == lexer.TokenComma || token.Type == lexer.TokenCloseParen || token.Type == lexer.TokenSemicolon {
 		return false
 	}
 	if prev.Type == lexer.TokenOpenParen {
@@ -50,18 +123,64 @@ func isSpaceAble(config Config, prev lexer.Token, token lexer.Token) bool {
 }
 
 var (
-	questionMark = []byte("?")
+	questionMark          = []byte("?")
+	collapsedUserVariable = []byte("@?")
+	collapsedInList       = []byte("(...)")
+	collapsedValues       = []byte("(?+)")
+)
+
+var (
+	keywordIN     = []byte("IN")
+	keywordVALUES = []byte("VALUES")
+	keywordVALUE  = []byte("VALUE")
 )
 
 var (
 	ErrBufferTooSmall = errors.New("buffer too small")
 )
 
+// Determine if token produces no output. Expanded executable comments
+// are handed back to the lexer, which then yields their content.
+func isSkipped(config Config, lex *lexer.Lexer, token lexer.Token) bool {
+	switch token.Type {
+	case lexer.TokenComment, lexer.TokenWhitespace, lexer.TokenUnknown:
+		return true
+	case lexer.TokenOptimizerHint:
+		return config.OptimizerHints == CommentStrip
+	case lexer.TokenExecutableComment:
+		switch config.ExecutableComments {
+		case CommentStrip:
+			return true
+		case CommentExpand:
+			if config.ServerVersion == 0 || token.MinVersion() <= config.ServerVersion {
+				lex.ExpandExecutableComment(token)
+			}
+			return true
+		}
+	}
+	return false
+}
+
 func Normalize(config Config, lex *lexer.Lexer, sql []byte, result []byte) (int, []byte, error) {
+	switch config.Format {
+	case FormatDigest:
+		return digestText(config, lex, sql, result)
+	case FormatFingerprint:
+		fp := fingerprint(string(sql))
+		if len(fp) > len(result) {
+			return 0, result[:0], ErrBufferTooSmall
+		}
+		n := copy(result, fp)
+		return n, result[:n], nil
+	}
 	lex.Parse(sql)
 	lex.Reset()
 	var prev lexer.Token
 	off := 0
+	identifierCase := config.IdentifierCase
+	if identifierCase == CaseDefault && config.LowerCaseTableNames != 0 {
+		identifierCase = CaseLower
+	}
 
 	for {
 		tok := lex.NextToken()
@@ -69,24 +188,28 @@ func Normalize(config Config, lex *lexer.Lexer, sql []byte, result []byte) (int,
 			break
 		}
 
+		if isSkipped(config, lex, tok) {
+			continue
+		}
+
 		var n int
 
 		if isSpaceAble(config, prev, tok) {
-			n = copy(result[off:], []byte{' '})
-			if n == 0 {
+			if off == len(result) {
 				return off, result[:off], ErrBufferTooSmall
 			}
-			off += n
+			result[off] = ' '
+			off++
 		}
 
 		n = 0
 		if tok.Type == lexer.TokenLiteral && config.RemoveLiterals {
 			n = copy(result[off:], questionMark)
-		} else if tok.Type == lexer.TokenComment {
-			// skip comments
-			goto end
+		} else if tok.IsUserVariable() && config.CollapseUserVariables {
+			n = copy(result[off:], collapsedUserVariable)
+		} else if tok.IsIdentifier() && config.IdentifierQuoting != QuoteKeep {
+			n = writeIdentifier(config, lex.Options().Dialect, tok, sql, result[off:])
 		} else {
-			n, _ = tok.LexemeWithRemovedBacktick(sql, result[off:])
 			n, _ = tok.Lexeme(sql, result[off:])
 		}
 		if n == 0 {
@@ -94,15 +217,168 @@ func Normalize(config Config, lex *lexer.Lexer, sql []byte, result []byte) (int,
 		}
 		off += n
 
-		if tok.IsKeyword() {
-			if config.KeywordCase == CaseLower {
-				bytes.ToLowerInPlace(result[off-n : off])
-			} else if config.KeywordCase == CaseUpper {
-				bytes.ToUpperInPlace(result[off-n : off])
-			}
+		if tok.IsIdentifier() {
+			changeCase(identifierCase, result[off-n:off])
+		} else if tok.IsKeyword() {
+			changeCase(config.KeywordCase, result[off-n:off])
 		}
-	end:
 		prev = tok
+
+		if collapsed := collapseList(config, lex, sql, tok); collapsed != nil {
+			n = copy(result[off:], collapsed)
+			if n < len(collapsed) {
+				return off, result[:off], ErrBufferTooSmall
+			}
+			off += n
+			prev = lexer.Token{Type: lexer.TokenCloseParen}
+		}
 	}
 	return off, result[:off], nil
 }
+
+// collapseList consumes the list following token when it is an IN list or
+// the rows of VALUES to be collapsed, and returns what to write instead.
+func collapseList(config Config, lex *lexer.Lexer, sql []byte, token lexer.Token) []byte {
+	if !token.IsBuiltInKeyword() {
+		return nil
+	}
+	lexeme := token.LexemeRef(sql)
+	var collapsed []byte
+	rows := false
+	switch {
+	case config.CollapseInLists && bytes.EqualFold(lexeme, keywordIN):
+		collapsed = collapsedInList
+	case config.CollapseValues && (bytes.EqualFold(lexeme, keywordVALUES) || bytes.EqualFold(lexeme, keywordVALUE)):
+		collapsed, rows = collapsedValues, true
+	default:
+		return nil
+	}
+
+	mark := lex.Mark()
+	for {
+		if !skipValueRow(config, lex) {
+			lex.Rewind(mark)
+			return nil
+		}
+		if !rows {
+			return collapsed
+		}
+		// another row follows a comma
+		m := lex.Mark()
+		if nextToken(config, lex).Type != lexer.TokenComma {
+			lex.Rewind(m)
+			return collapsed
+		}
+	}
+}
+
+// skipValueRow consumes `(value, ...)`, reporting whether each value is a
+// literal or a placeholder.
+func skipValueRow(config Config, lex *lexer.Lexer) bool {
+	if nextToken(config, lex).Type != lexer.TokenOpenParen {
+		return false
+	}
+	for {
+		tok := nextToken(config, lex)
+		if !tok.IsLiteral() && !tok.IsPlaceholder() && tok.Attr&lexer.TokenAttrLiteralKind == 0 {
+			return false
+		}
+		switch nextToken(config, lex).Type {
+		case lexer.TokenComma:
+		case lexer.TokenCloseParen:
+			return true
+		default:
+			return false
+		}
+	}
+}
+
+// nextToken returns the next token producing output.
+func nextToken(config Config, lex *lexer.Lexer) lexer.Token {
+	for {
+		tok := lex.NextToken()
+		if tok.Type == lexer.TokenEOF || !isSkipped(config, lex, tok) {
+			return tok
+		}
+	}
+}
+
+func changeCase(c Case, b []byte) {
+	switch c {
+	case CaseLower:
+		bytes.ToLowerInPlace(b)
+	case CaseUpper:
+		bytes.ToUpperInPlace(b)
+	}
+}
+
+// writeIdentifier writes the identifier token quoted as config says and
+// returns the number of bytes written, 0 if result is too small.
+func writeIdentifier(config Config, dialect lexer.Dialect, token lexer.Token, sql []byte, result []byte) int {
+	lexeme := token.LexemeRef(sql)
+	name, quote := lexeme, byte(0)
+	if c := lexeme[0]; c == '`' || c == '"' {
+		if token.Attr&lexer.TokenAttrUnterminated != 0 || len(lexeme) < 2 {
+			n, _ := token.Lexeme(sql, result)
+			return n
+		}
+		name, quote = lexeme[1:len(lexeme)-1], c
+	}
+
+	var to byte
+	switch config.IdentifierQuoting {
+	case QuoteAlways:
+		to = '`'
+	case QuoteWhenNeeded:
+		if needsQuoting(name, dialect) {
+			to = '`'
+		}
+	}
+	return requote(result, name, quote, to)
+}
+
+func needsQuoting(name []byte, dialect lexer.Dialect) bool {
+	if len(name) == 0 || name[0] >= '0' && name[0] <= '9' {
+		return true
+	}
+	for _, c := range name {
+		if c < utf8.RuneSelf && c != '_' && c != '$' && !(c >= '0' && c <= '9') &&
+			!(c >= 'a' && c <= 'z') && !(c >= 'A' && c <= 'Z') {
+			return true
+		}
+	}
+	return lexer.IsReservedKeyword(name, dialect)
+}
+
+// requote writes name, quoted with from (0 if not quoted), quoted with to
+// instead, undoing and doing the doubling of the quote characters.
+func requote(result []byte, name []byte, from byte, to byte) int {
+	n := 0
+	put := func(c byte) bool {
+		if n == len(result) {
+			return false
+		}
+		result[n] = c
+		n++
+		return true
+	}
+	if to != 0 && !put(to) {
+		return 0
+	}
+	for i := 0; i < len(name); i++ {
+		c := name[i]
+		if from != 0 && c == from && i+1 < len(name) && name[i+1] == from {
+			i++
+		}
+		if to != 0 && c == to && !put(c) {
+			return 0
+		}
+		if !put(c) {
+			return 0
+		}
+	}
+	if to != 0 && !put(to) {
+		return 0
+	}
+	return n
+}
@@ -38,18 +38,18 @@ var benchConfigs = []struct {
 	{
 		name: "FullFeatures",
 		config: Config{
-			KeywordCase:    CaseUpper,
-			RemoveLiterals: true,
-			// PutBacktickOnKeywords:   true,
+			KeywordCase:       CaseUpper,
+			RemoveLiterals:    true,
+			IdentifierQuoting: QuoteAlways,
 			// PutSpaceBeforeOpenParen: true,
 		},
 	},
 	{
 		name: "RemoveBackticks",
 		config: Config{
-			KeywordCase:    CaseLower,
-			RemoveLiterals: true,
-			// RemoveBacktickOnKeywords: true,
+			KeywordCase:       CaseLower,
+			RemoveLiterals:    true,
+			IdentifierQuoting: QuoteNever,
 		},
 	},
 }
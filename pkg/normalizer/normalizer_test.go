@@ -24,7 +24,7 @@ func TestNormalize(t *testing.T) {
 				RemoveLiterals: true,
 			},
 			input:    "SELECT id, name FROM users WHERE age = 25",
-			expected: "SELECT ID, NAME FROM USERS WHERE AGE = ?",
+			expected: "SELECT id, name FROM users WHERE age = ?",
 		},
 		{
 			name: "lowercase keywords",
@@ -42,7 +42,7 @@ func TestNormalize(t *testing.T) {
 				RemoveLiterals: false,
 			},
 			input:    "select * from users where name = 'john'",
-			expected: "SELECT * FROM USERS WHERE NAME = 'john'",
+			expected: "SELECT * FROM users WHERE name = 'john'",
 		},
 		{
 			name: "complex query with joins",
@@ -51,40 +51,40 @@ func TestNormalize(t *testing.T) {
 				RemoveLiterals: true,
 			},
 			input:    "SELECT u.id, u.name, p.title, FROM users u JOIN posts p ON u.id = p.user_id WHERE u.age > 18",
-			expected: "SELECT U.ID, U.NAME, P.TITLE, FROM USERS U JOIN POSTS P ON U.ID = P.USER_ID WHERE U.AGE > ?",
+			expected: "SELECT u.id, u.name, p.title, FROM users u JOIN posts p ON u.id = p.user_id WHERE u.age > ?",
 		},
 		{
 			name: "complex query with joins with backticks",
 			config: Config{
-				KeywordCase:    CaseUpper,
-				RemoveLiterals: true,
-				// PutBacktickOnKeywords: true,
+				KeywordCase:       CaseUpper,
+				RemoveLiterals:    true,
+				IdentifierQuoting: QuoteWhenNeeded,
 			},
 			// try to put
 			// builtin keyword after dot here
 			// should not be treated as a keyword, so should be backticked
 			input:    "SELECT u.id, u.name, p.title, p.select FROM users u JOIN posts p ON u.id = p.user_id WHERE u.age > 18",
-			expected: "SELECT U.ID, U.NAME, P.TITLE, P.SELECT FROM USERS U JOIN POSTS P ON U.ID = P.USER_ID WHERE U.AGE > ?",
+			expected: "SELECT u.id, u.name, p.title, p.`select` FROM users u JOIN posts p ON u.id = p.user_id WHERE u.age > ?",
 		},
 		{
 			name: "complex query with joins with backticks and comments",
 			config: Config{
-				KeywordCase:    CaseUpper,
-				RemoveLiterals: true,
-				// PutBacktickOnKeywords: true,
+				KeywordCase:       CaseUpper,
+				RemoveLiterals:    true,
+				IdentifierQuoting: QuoteWhenNeeded,
 			},
 			// try to put
 			// builtin keyword after dot here
 			// should not be treated as a keyword, so should be backticked
 			input:    "SELECT u.id, u.name, p.title, p.select FROM users u JOIN posts p ON u.id = p.user_id -- WHERE u.age > 18",
-			expected: "SELECT U.ID, U.NAME, P.TITLE, P.SELECT FROM USERS U JOIN POSTS P ON U.ID = P.USER_ID",
+			expected: "SELECT u.id, u.name, p.title, p.`select` FROM users u JOIN posts p ON u.id = p.user_id",
 		},
 		{
 			name: "complex query with joins with backticks and comments in the middle",
 			config: Config{
-				KeywordCase:    CaseUpper,
-				RemoveLiterals: true,
-				// PutBacktickOnKeywords: true,
+				KeywordCase:       CaseUpper,
+				RemoveLiterals:    true,
+				IdentifierQuoting: QuoteWhenNeeded,
 			},
 			// try to put
 			// builtin keyword after dot here
@@ -99,7 +99,7 @@ FROM users u
 JOIN posts p 
 	ON u.id = p.user_id
 -- WHERE u.age > 18`,
-			expected: `SELECT U.ID, U.NAME, P.TITLE FROM USERS U JOIN POSTS P ON U.ID = P.USER_ID`,
+			expected: `SELECT u.id, u.name, p.title FROM users u JOIN posts p ON u.id = p.user_id`,
 		},
 		{
 			name: "insert statement",
@@ -117,7 +117,7 @@ JOIN posts p
 				RemoveLiterals: true,
 			},
 			input:    "UPDATE users SET name = 'Jane Doe', age = 25 WHERE id = 1",
-			expected: "UPDATE USERS SET NAME = ?, AGE = ? WHERE ID = ?",
+			expected: "UPDATE users SET name = ?, age = ? WHERE id = ?",
 		},
 		{
 			name: "delete statement",
@@ -135,7 +135,7 @@ JOIN posts p
 				RemoveLiterals: true,
 			},
 			input:    "SELECT * FROM users WHERE id IN (SELECT user_id FROM orders WHERE total > 100)",
-			expected: "SELECT * FROM USERS WHERE ID IN(SELECT USER_ID FROM ORDERS WHERE TOTAL > ?)",
+			expected: "SELECT * FROM users WHERE id IN(SELECT user_id FROM orders WHERE total > ?)",
 		},
 		{
 			name: "function calls",
@@ -143,7 +143,7 @@ JOIN posts p
 				KeywordCase:    CaseLower,
 				RemoveLiterals: true,
 				// PutSpaceBeforeOpenParen: true,
-				// PutBacktickOnKeywords:   true,
+				IdentifierQuoting: QuoteWhenNeeded,
 			},
 			input:    "SELECT COUNT(*), MAX(age), MIN(created_at) FROM users WHERE name LIKE '%john%'",
 			expected: "select count(*), max(age), min(created_at) from users where name like ?",
@@ -154,10 +154,10 @@ JOIN posts p
 				KeywordCase:    CaseUpper,
 				RemoveLiterals: false,
 				// PutSpaceBeforeOpenParen: true,
-				// PutBacktickOnKeywords:   true,
+				IdentifierQuoting: QuoteWhenNeeded,
 			},
 			input:    "SELECT name, ROW_NUMBER () OVER (ORDER BY age DESC) as `rank` FROM users",
-			expected: "SELECT NAME, ROW_NUMBER() OVER(ORDER BY AGE DESC) AS `RANK` FROM USERS",
+			expected: "SELECT name, ROW_NUMBER() OVER(ORDER BY age DESC) AS `rank` FROM users",
 		},
 		{
 			name: "empty query",
@@ -193,7 +193,7 @@ JOIN posts p
 				RemoveLiterals: true,
 			},
 			input:    `SELECT * FROM users WHERE name = "John's User" AND description = 'He said "Hello"'`,
-			expected: "SELECT * FROM USERS WHERE NAME = ? AND DESCRIPTION = ?",
+			expected: "SELECT * FROM users WHERE name = ? AND description = ?",
 		},
 		{
 			name: "case statement",
@@ -202,7 +202,7 @@ JOIN posts p
 				RemoveLiterals: true,
 			},
 			input:    "SELECT name, CASE WHEN age < 18 THEN 'minor' ELSE 'adult' END as category FROM users",
-			expected: "SELECT NAME, CASE WHEN AGE < ? THEN ? ELSE ? END AS CATEGORY FROM USERS",
+			expected: "SELECT name, CASE WHEN age < ? THEN ? ELSE ? END AS category FROM users",
 		},
 		{
 			name: "union query",
@@ -213,6 +213,67 @@ JOIN posts p
 			input:    "SELECT name FROM users WHERE active = 1 UNION SELECT name FROM admins WHERE active = 1",
 			expected: "select name from users where active = 1 union select name from admins where active = 1",
 		},
+		{
+			name: "placeholders pass through",
+			config: Config{
+				KeywordCase:    CaseUpper,
+				RemoveLiterals: true,
+			},
+			input:    "SELECT * FROM users WHERE id = ? AND name = :name AND age > $1 AND status IN (?, ?)",
+			expected: "SELECT * FROM users WHERE id = ? AND name = :name AND age > $1 AND status IN(?, ?)",
+		},
+		{
+			name: "user and system variables kept",
+			config: Config{
+				KeywordCase:    CaseUpper,
+				RemoveLiterals: true,
+			},
+			input:    "SELECT @x := 1, @@global.max_connections, @x",
+			expected: "SELECT @x := ?, @@global.max_connections, @x",
+		},
+		{
+			name: "user variables collapsed",
+			config: Config{
+				KeywordCase:           CaseUpper,
+				RemoveLiterals:        true,
+				CollapseUserVariables: true,
+			},
+			input:    "SELECT @total := @total + amount, @'quoted', @@session.sql_mode FROM payments",
+			expected: "SELECT @? := @? + amount, @?, @@session.sql_mode FROM payments",
+		},
+		{
+			name: "prefixed literals",
+			config: Config{
+				KeywordCase:    CaseUpper,
+				RemoveLiterals: true,
+			},
+			input:    "SELECT * FROM t WHERE name = _utf8mb4'abc' COLLATE utf8mb4_bin AND n = N'x' AND d >= DATE '2024-01-01' AND b = _binary 0x00",
+			expected: "SELECT * FROM t WHERE name = ? COLLATE utf8mb4_bin AND n = ? AND d >= ? AND b = ?",
+		},
+		{
+			name:     "collapsed IN lists",
+			config:   Config{KeywordCase: CaseUpper, RemoveLiterals: true, CollapseInLists: true},
+			input:    "SELECT * FROM t WHERE a IN (1, 2, 3) AND b NOT IN (?, 'x', NULL) AND c IN (1, d) AND e IN (SELECT f FROM u)",
+			expected: "SELECT * FROM t WHERE a IN(...) AND b NOT IN(...) AND c IN(?, d) AND e IN(SELECT f FROM u)",
+		},
+		{
+			name:     "collapsed IN lists with expanded comments",
+			config:   Config{KeywordCase: CaseLower, RemoveLiterals: true, CollapseInLists: true, ExecutableComments: CommentExpand},
+			input:    "SELECT a FROM t WHERE a IN (/*! 1, */ 2 /* two */)",
+			expected: "select a from t where a in(...)",
+		},
+		{
+			name:     "collapsed VALUES rows",
+			config:   Config{KeywordCase: CaseUpper, RemoveLiterals: true, CollapseValues: true},
+			input:    "INSERT INTO t (a, b) VALUES (1, 'x'), (2, 'y'), (3, NULL) ON DUPLICATE KEY UPDATE b = VALUES(b)",
+			expected: "INSERT INTO t(a, b) VALUES(?+) ON DUPLICATE KEY UPDATE b = VALUES(b)",
+		},
+		{
+			name:     "VALUES rows with expressions are kept",
+			config:   Config{KeywordCase: CaseUpper, RemoveLiterals: true, CollapseValues: true},
+			input:    "INSERT INTO t VALUES (1, NOW()), (2, NOW())",
+			expected: "INSERT INTO t VALUES(?, NOW()), (?, NOW())",
+		},
 	}
 
 	for _, tt := range tests {
@@ -232,6 +293,228 @@ JOIN posts p
 	}
 }
 
+func TestNormalize_ExecutableCommentsAndHints(t *testing.T) {
+	lex := lexer.NewLexer()
+
+	tests := []struct {
+		name     string
+		config   Config
+		input    string
+		expected string
+	}{
+		{
+			name:     "kept by default",
+			config:   Config{KeywordCase: CaseUpper, RemoveLiterals: true},
+			input:    "SELECT /*+ INDEX(t idx_a) */ a FROM t /*!50100 PARTITION (p0) */ WHERE a = 1",
+			expected: "SELECT /*+ INDEX(t idx_a) */ a FROM t /*!50100 PARTITION (p0) */ WHERE a = ?",
+		},
+		{
+			name:     "stripped",
+			config:   Config{KeywordCase: CaseUpper, ExecutableComments: CommentStrip, OptimizerHints: CommentStrip},
+			input:    "SELECT /*+ INDEX(t idx_a) */ a FROM t /*!50100 PARTITION (p0) */ WHERE a = 1",
+			expected: "SELECT a FROM t WHERE a = 1",
+		},
+		{
+			name:     "expanded for every version",
+			config:   Config{KeywordCase: CaseUpper, RemoveLiterals: true, ExecutableComments: CommentExpand},
+			input:    "SELECT /*! STRAIGHT_JOIN */ a FROM t /*!50100 PARTITION (p0) */ WHERE a = 1",
+			expected: "SELECT STRAIGHT_JOIN a FROM t PARTITION(p0) WHERE a = ?",
+		},
+		{
+			name:     "expanded only up to server version",
+			config:   Config{KeywordCase: CaseLower, RemoveLiterals: true, ExecutableComments: CommentExpand, ServerVersion: 80034},
+			input:    "SELECT a /*!80000 , b */ /*!90000 , c */ FROM t WHERE a IN (/*! 1, */ 2)",
+			expected: "select a, b from t where a in(?, ?)",
+		},
+		{
+			name:     "six digit version",
+			config:   Config{KeywordCase: CaseUpper, ExecutableComments: CommentExpand, ServerVersion: 80034},
+			input:    "SELECT 1 /*!080035 + 2 */",
+			expected: "SELECT 1",
+		},
+		{
+			name:     "hints are not expanded",
+			config:   Config{KeywordCase: CaseUpper, ExecutableComments: CommentExpand, OptimizerHints: CommentExpand},
+			input:    "SELECT /*+ MAX_EXECUTION_TIME(1000) */ * FROM t",
+			expected: "SELECT /*+ MAX_EXECUTION_TIME(1000) */ * FROM t",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := make([]byte, len(tt.input)*2)
+			_, normalized, err := Normalize(tt.config, lex, []byte(tt.input), result)
+			if err != nil {
+				t.Errorf("Normalize() error = %v", err)
+				return
+			}
+
+			actual := string(normalized)
+			if actual != tt.expected {
+				t.Errorf("Normalize() = %q, want %q", actual, tt.expected)
+			}
+		})
+	}
+}
+
+func TestNormalize_SQLMode(t *testing.T) {
+	config := Config{KeywordCase: CaseUpper, RemoveLiterals: true}
+	input := `SELECT "first name" FROM users WHERE note = 'C:\dir\' AND "id" = 1`
+
+	tests := []struct {
+		name     string
+		mode     lexer.SQLMode
+		expected string
+	}{
+		{
+			name:     "default",
+			expected: `SELECT ? FROM users WHERE note = ?`,
+		},
+		{
+			name:     "ansi quotes and no backslash escapes",
+			mode:     lexer.SQLModeANSIQuotes | lexer.SQLModeNoBackslashEscapes,
+			expected: `SELECT "first name" FROM users WHERE note = ? AND "id" = ?`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			lex := lexer.NewLexer(lexer.Options{SQLMode: tt.mode})
+			result := make([]byte, len(input)*2)
+			_, normalized, err := Normalize(config, lex, []byte(input), result)
+			if err != nil {
+				t.Errorf("Normalize() error = %v", err)
+				return
+			}
+			if string(normalized) != tt.expected {
+				t.Errorf("Normalize() = %q, want %q", normalized, tt.expected)
+			}
+		})
+	}
+}
+
+func TestNormalize_IdentifierCase(t *testing.T) {
+	lex := lexer.NewLexer()
+	input := "select Users.Email, `Name` from Users where p.`select` = 1 order by Email"
+
+	tests := []struct {
+		name     string
+		config   Config
+		expected string
+	}{
+		{
+			name:     "preserved",
+			config:   Config{KeywordCase: CaseUpper},
+			expected: "SELECT Users.Email, `Name` FROM Users WHERE p.`select` = 1 ORDER BY Email",
+		},
+		{
+			name:     "lower",
+			config:   Config{KeywordCase: CaseUpper, IdentifierCase: CaseLower},
+			expected: "SELECT users.email, `name` FROM users WHERE p.`select` = 1 ORDER BY email",
+		},
+		{
+			name:     "upper",
+			config:   Config{KeywordCase: CaseLower, IdentifierCase: CaseUpper},
+			expected: "select USERS.EMAIL, `NAME` from USERS where P.`SELECT` = 1 order by EMAIL",
+		},
+		{
+			name:     "case sensitive table names",
+			config:   Config{KeywordCase: CaseUpper, LowerCaseTableNames: 0},
+			expected: "SELECT Users.Email, `Name` FROM Users WHERE p.`select` = 1 ORDER BY Email",
+		},
+		{
+			name:     "case insensitive table names",
+			config:   Config{KeywordCase: CaseUpper, LowerCaseTableNames: 2},
+			expected: "SELECT users.email, `name` FROM users WHERE p.`select` = 1 ORDER BY email",
+		},
+		{
+			name:     "identifier case over table names",
+			config:   Config{LowerCaseTableNames: 1, IdentifierCase: CaseUpper},
+			expected: "select USERS.EMAIL, `NAME` from USERS where P.`SELECT` = 1 order by EMAIL",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := make([]byte, len(input)*2)
+			_, normalized, err := Normalize(tt.config, lex, []byte(input), result)
+			if err != nil {
+				t.Errorf("Normalize() error = %v", err)
+				return
+			}
+			if string(normalized) != tt.expected {
+				t.Errorf("Normalize() = %q, want %q", normalized, tt.expected)
+			}
+		})
+	}
+}
+
+func TestNormalize_IdentifierQuoting(t *testing.T) {
+	input := "SELECT id, `name`, p.select, `a b`, `1a`, `x``y` FROM users JOIN `users` p"
+
+	tests := []struct {
+		name     string
+		quoting  IdentifierQuoting
+		mode     lexer.SQLMode
+		input    string
+		expected string
+	}{
+		{
+			name:     "keep",
+			quoting:  QuoteKeep,
+			input:    input,
+			expected: "SELECT id, `name`, p.select, `a b`, `1a`, `x``y` FROM users JOIN `users` p",
+		},
+		{
+			name:     "always",
+			quoting:  QuoteAlways,
+			input:    input,
+			expected: "SELECT `id`, `name`, `p`.`select`, `a b`, `1a`, `x``y` FROM `users` JOIN `users` `p`",
+		},
+		{
+			name:     "never",
+			quoting:  QuoteNever,
+			input:    input,
+			expected: "SELECT id, name, p.select, a b, 1a, x`y FROM users JOIN users p",
+		},
+		{
+			name:     "when needed",
+			quoting:  QuoteWhenNeeded,
+			input:    input,
+			expected: "SELECT id, name, p.`select`, `a b`, `1a`, `x``y` FROM users JOIN users p",
+		},
+		{
+			name:     "quoted keywords are identifiers",
+			quoting:  QuoteWhenNeeded,
+			input:    "SELECT `select`, `Count`(1) FROM `from`",
+			expected: "SELECT `select`, Count(1) FROM `from`",
+		},
+		{
+			name:     "ansi quotes",
+			quoting:  QuoteWhenNeeded,
+			mode:     lexer.SQLModeANSIQuotes,
+			input:    `SELECT "id", "a""b", "a` + "`" + `b" FROM t`,
+			expected: "SELECT id, `a\"b`, `a``b` FROM t",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			lex := lexer.NewLexer(lexer.Options{SQLMode: tt.mode})
+			config := Config{IdentifierQuoting: tt.quoting}
+			result := make([]byte, len(tt.input)*2)
+			_, normalized, err := Normalize(config, lex, []byte(tt.input), result)
+			if err != nil {
+				t.Errorf("Normalize() error = %v", err)
+				return
+			}
+			if string(normalized) != tt.expected {
+				t.Errorf("Normalize() = %q, want %q", normalized, tt.expected)
+			}
+		})
+	}
+}
+
 // Test all configuration combinations systematically
 func TestNormalize_AllConfigCombinations(t *testing.T) {
 	lex := lexer.NewLexer()
@@ -253,11 +536,11 @@ func TestNormalize_AllConfigCombinations(t *testing.T) {
 			expected: map[string]string{
 				"default_false_false_false_false": "SELECT `user_id`, `full_name` FROM `user_table` WHERE `age` = 25",
 				"lower_false_false_false_false":   "select `user_id`, `full_name` from `user_table` where `age` = 25",
-				"upper_false_false_false_false":   "SELECT `USER_ID`, `FULL_NAME` FROM `USER_TABLE` WHERE `AGE` = 25",
-				"upper_true_false_false_false":    "SELECT `USER_ID`, `FULL_NAME` FROM `USER_TABLE` WHERE `AGE` = ?",
-				"upper_false_true_false_false":    "SELECT `USER_ID`, `FULL_NAME` FROM `USER_TABLE` WHERE `AGE` = 25",
-				"upper_false_false_true_false":    "SELECT `USER_ID`, `FULL_NAME` FROM `USER_TABLE` WHERE `AGE` = 25",
-				"upper_false_true_false_true":     "SELECT `USER_ID`, `FULL_NAME` FROM `USER_TABLE` WHERE `AGE` = 25",
+				"upper_false_false_false_false":   "SELECT `user_id`, `full_name` FROM `user_table` WHERE `age` = 25",
+				"upper_true_false_false_false":    "SELECT `user_id`, `full_name` FROM `user_table` WHERE `age` = ?",
+				"upper_false_true_false_false":    "SELECT `user_id`, `full_name` FROM `user_table` WHERE `age` = 25",
+				"upper_false_false_true_false":    "SELECT user_id, full_name FROM user_table WHERE age = 25",
+				"upper_false_true_false_true":     "SELECT `user_id`, `full_name` FROM `user_table` WHERE `age` = 25",
 			},
 		},
 		{
@@ -265,8 +548,8 @@ func TestNormalize_AllConfigCombinations(t *testing.T) {
 			input: "SELECT COUNT(*), MAX(age) FROM users WHERE name LIKE 'John%'",
 			expected: map[string]string{
 				"default_false_false_false_false": "SELECT COUNT(*), MAX(age) FROM users WHERE name LIKE 'John%'",
-				"lower_true_true_false_true":      "select count(*), max(age) from users where name like ?",
-				"upper_true_true_false_true":      "SELECT COUNT(*), MAX(AGE) FROM USERS WHERE NAME LIKE ?",
+				"lower_true_true_false_true":      "select count(*), max(`age`) from `users` where `name` like ?",
+				"upper_true_true_false_true":      "SELECT COUNT(*), MAX(`age`) FROM `users` WHERE `name` LIKE ?",
 			},
 		},
 		{
@@ -274,15 +557,15 @@ func TestNormalize_AllConfigCombinations(t *testing.T) {
 			input: "SELECT user_id, full_name FROM user_table WHERE `status` = 'active'",
 			expected: map[string]string{
 				"default_false_false_false_false": "SELECT user_id, full_name FROM user_table WHERE `status` = 'active'",
-				"upper_false_true_false_false":    "SELECT USER_ID, FULL_NAME FROM USER_TABLE WHERE `STATUS` = 'active'",
-				"lower_true_true_false_false":     "select user_id, full_name from user_table where `status` = ?",
+				"upper_false_true_false_false":    "SELECT `user_id`, `full_name` FROM `user_table` WHERE `status` = 'active'",
+				"lower_true_true_false_false":     "select `user_id`, `full_name` from `user_table` where `status` = ?",
 			},
 		},
 		{
 			name:  "mixed_quotes_and_literals",
 			input: `SELECT * FROM users WHERE name = "John's Data" AND age = 25 AND score = 99.5`,
 			expected: map[string]string{
-				"upper_true_true_false_false":   "SELECT * FROM USERS WHERE NAME = ? AND AGE = ? AND SCORE = ?",
+				"upper_true_true_false_false":   "SELECT * FROM `users` WHERE `name` = ? AND `age` = ? AND `score` = ?",
 				"lower_false_false_false_false": `select * from users where name = "John's Data" and age = 25 and score = 99.5`,
 			},
 		},
@@ -300,15 +583,21 @@ func TestNormalize_AllConfigCombinations(t *testing.T) {
 							continue
 						}
 						if spaceBeforeParen && !putBacktick {
-							// PutSpaceBeforeOpenParen requires PutBacktickOnKeywords to be true
+							// PutSpaceBeforeOpenParen requires backticks to be put
 							continue
 						}
 
+						quoting := QuoteKeep
+						if putBacktick {
+							quoting = QuoteAlways
+						} else if removeBacktick {
+							quoting = QuoteNever
+						}
+
 						config := Config{
-							KeywordCase:    keywordCase,
-							RemoveLiterals: removeLiterals,
-							// PutBacktickOnKeywords:    putBacktick,
-							// RemoveBacktickOnKeywords: removeBacktick,
+							KeywordCase:       keywordCase,
+							RemoveLiterals:    removeLiterals,
+							IdentifierQuoting: quoting,
 							// PutSpaceBeforeOpenParen: spaceBeforeParen,
 						}
 
@@ -441,11 +730,16 @@ func TestNormalize_EdgeCases(t *testing.T) {
 			input:    "   \t\n\r  ",
 			expected: "",
 		},
-		// {
-		// 	name:     "only_comments",
-		// 	input:    "-- this is a comment\n/* block comment */",
-		// 	expected: "",
-		// },
+		{
+			name:     "only_comments",
+			input:    "-- this is a comment\n/* block comment */",
+			expected: "",
+		},
+		{
+			name:     "leading_comment",
+			input:    "/* app=web */ SELECT 1",
+			expected: "SELECT ?",
+		},
 		{
 			name:     "single_keyword",
 			input:    "SELECT",
@@ -459,12 +753,27 @@ func TestNormalize_EdgeCases(t *testing.T) {
 		{
 			name:     "unterminated_string",
 			input:    "SELECT * FROM users WHERE name = 'unterminated",
-			expected: "SELECT * FROM USERS WHERE NAME = ?",
+			expected: "SELECT * FROM users WHERE name = ?",
+		},
+		{
+			name:     "utf8_identifiers",
+			input:    "SELECT prix$eur FROM café_orders WHERE 名前 = 'x'",
+			expected: "SELECT prix$eur FROM café_orders WHERE 名前 = ?",
+		},
+		{
+			name:     "semicolons",
+			input:    "SELECT a=-1 ; SELECT b<-2;",
+			expected: "SELECT a = ?; SELECT b < ?;",
+		},
+		{
+			name:     "unterminated_identifier",
+			input:    "SELECT * FROM `users",
+			expected: "SELECT * FROM `users",
 		},
 		{
 			name:     "empty_parens",
 			input:    "SELECT COUNT() FROM users",
-			expected: "SELECT COUNT() FROM USERS",
+			expected: "SELECT COUNT() FROM users",
 		},
 		{
 			name:     "nested_parens",
@@ -474,7 +783,7 @@ func TestNormalize_EdgeCases(t *testing.T) {
 		{
 			name:     "special_characters",
 			input:    "SELECT * FROM `table-name` WHERE `col@name` = 'val#ue'",
-			expected: "SELECT * FROM `TABLE-NAME` WHERE `COL@NAME` = ?",
+			expected: "SELECT * FROM `table-name` WHERE `col@name` = ?",
 		},
 		// {
 		// 	name:     "unicode_identifiers",
@@ -484,7 +793,7 @@ func TestNormalize_EdgeCases(t *testing.T) {
 		{
 			name:     "very_long_identifier",
 			input:    "SELECT " + strings.Repeat("very_long_column_name_", 20) + " FROM users",
-			expected: "SELECT " + strings.ToUpper(strings.Repeat("very_long_column_name_", 20)) + " FROM USERS",
+			expected: "SELECT " + strings.Repeat("very_long_column_name_", 20) + " FROM users",
 		},
 		// {
 		// 	name:     "sql_injection_attempt",
@@ -494,7 +803,7 @@ func TestNormalize_EdgeCases(t *testing.T) {
 		{
 			name:     "binary_data_simulation",
 			input:    "SELECT * FROM files WHERE content = 'binary\x00\x01\x02'",
-			expected: "SELECT * FROM FILES WHERE CONTENT = ?",
+			expected: "SELECT * FROM files WHERE content = ?",
 		},
 	}
 
@@ -520,7 +829,7 @@ func TestNormalize_BufferSizes(t *testing.T) {
 	lex := lexer.NewLexer()
 	config := Config{KeywordCase: CaseUpper, RemoveLiterals: false}
 	input := "SELECT id FROM users WHERE name = 'test'"
-	expected := "SELECT ID FROM USERS WHERE NAME = 'test'"
+	expected := "SELECT id FROM users WHERE name = 'test'"
 
 	testCases := []struct {
 		name           string
@@ -593,10 +902,10 @@ func FuzzNormalize(f *testing.F) {
 		{KeywordCase: CaseLower, RemoveLiterals: false},
 		{KeywordCase: CaseUpper, RemoveLiterals: false},
 		{KeywordCase: CaseUpper, RemoveLiterals: true},
-		{KeywordCase: CaseLower, RemoveLiterals: true /* PutBacktickOnKeywords: true */},
-		{KeywordCase: CaseUpper, RemoveLiterals: true /*  RemoveBacktickOnKeywords: true */},
-		{KeywordCase: CaseDefault, RemoveLiterals: false /*  PutBacktickOnKeywords: true */ /* PutSpaceBeforeOpenParen: true */},
-		{KeywordCase: CaseUpper, RemoveLiterals: true /* PutBacktickOnKeywords: true */ /* PutSpaceBeforeOpenParen: true */},
+		{KeywordCase: CaseLower, RemoveLiterals: true, IdentifierQuoting: QuoteAlways},
+		{KeywordCase: CaseUpper, RemoveLiterals: true, IdentifierQuoting: QuoteNever},
+		{KeywordCase: CaseDefault, RemoveLiterals: false, IdentifierQuoting: QuoteAlways /* PutSpaceBeforeOpenParen: true */},
+		{KeywordCase: CaseUpper, RemoveLiterals: true, IdentifierQuoting: QuoteWhenNeeded /* PutSpaceBeforeOpenParen: true */},
 	}
 
 	lex := lexer.NewLexer()
@@ -731,6 +1040,35 @@ func TestNormalize_Properties(t *testing.T) {
 		}
 	})
 
+	// Property: normalizing an already normalized query is a no-op
+	t.Run("normalize_is_idempotent", func(t *testing.T) {
+		testCases := []string{
+			"SELECT * FROM users WHERE id = 123 AND name = 'test'",
+			"INSERT INTO users VALUES(1, 'john', 25, 'active')",
+			"SELECT * FROM users WHERE id IN (1, 2, 3) AND token = :token",
+		}
+
+		config := Config{KeywordCase: CaseUpper, RemoveLiterals: true}
+		for _, input := range testCases {
+			result1 := make([]byte, len(input)*2)
+			_, normalized1, err := Normalize(config, lex, []byte(input), result1)
+			if err != nil {
+				t.Errorf("Normalize() error = %v", err)
+				return
+			}
+
+			result2 := make([]byte, len(normalized1)*2)
+			_, normalized2, err := Normalize(config, lex, normalized1, result2)
+			if err != nil {
+				t.Errorf("Normalize() error = %v", err)
+				return
+			}
+
+			if string(normalized1) != string(normalized2) {
+				t.Errorf("Normalize() is not idempotent: first=%q, second=%q", normalized1, normalized2)
+			}
+		}
+	})
 }
 
 func max(a, b int) int {